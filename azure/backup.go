@@ -0,0 +1,74 @@
+package azure
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// BackupSecret returns a backup of every version of the secret with the
+// given name, as an encrypted blob that only RestoreSecret on a vault of the
+// same subscription and geography can read.
+func (ksm *KeyVaultSecretsManager) BackupSecret(name string) ([]byte, *errors.Error) {
+	vaultName, err := ksm.kvClient.vaultSecretName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	start := ksm.kvClient.now()
+	var resp azsecrets.BackupSecretResponse
+	err = ksm.kvClient.retry(ksm.kvClient.ctx, func() *errors.Error {
+		var err error
+		resp, err = ksm.secretsClient.BackupSecret(ksm.kvClient.ctx, vaultName, nil)
+		if err != nil {
+			return ksm.kvClient.azError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, ksm.kvClient.operationError(ksm.kvClient.ctx, opBackupSecret, name, start, err)
+	}
+
+	return resp.Value, nil
+}
+
+// RestoreSecret restores a secret from a blob returned by BackupSecret and
+// returns it, without its value. A secret of the same name already in the
+// vault, even soft-deleted, is a Conflict error. The name is only known once
+// restored, so clients scoped with WithNameAllowlist or WithNameDenylist
+// cannot restore at all: RestoreSecret is an InsufficientAccess error for
+// them, without writing anything.
+func (ksm *KeyVaultSecretsManager) RestoreSecret(blob []byte) (*Secret, *errors.Error) {
+	if ksm.kvClient.nameScoped() {
+		return nil, errors.InsufficientAccessError("RestoreSecret cannot check the name of the secret against the names allowed for this client before writing it")
+	}
+
+	params := azsecrets.RestoreSecretParameters{SecretBackup: blob}
+
+	start := ksm.kvClient.now()
+	var resp azsecrets.RestoreSecretResponse
+	err := ksm.kvClient.retry(ksm.kvClient.ctx, func() *errors.Error {
+		var err error
+		resp, err = ksm.secretsClient.RestoreSecret(ksm.kvClient.ctx, params, nil)
+		if err != nil {
+			return ksm.kvClient.azError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, ksm.kvClient.operationError(ksm.kvClient.ctx, opRestoreSecret, "", start, err)
+	}
+	if resp.ID == nil {
+		return nil, errors.InternalServerError("restored secret has no ID")
+	}
+
+	name := ksm.kvClient.callerSecretName(resp.ID.Name())
+	ksm.invalidateExistence(name)
+
+	secret := &Secret{Name: name, Version: resp.ID.Version(), redaction: ksm.kvClient.redactionStyle}
+	applyAttributes(secret, resp.Attributes)
+	secret.ContentType = derefString(resp.ContentType)
+	secret.Tags = fromAzTags(resp.Tags)
+
+	return secret, nil
+}
@@ -0,0 +1,42 @@
+package azure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestBackupRestore(t *testing.T) {
+	src := newTestManager(context.Background(), &fakeOps{})
+	blob, err := src.BackupSecret("db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := newTestManager(context.Background(), &fakeOps{})
+	s, err := dst.RestoreSecret(blob)
+	if err != nil || s.Name != "db" || s.Version != "v9" {
+		t.Fatal(s, err)
+	}
+	if _, err := dst.RestoreSecret(blob); err == nil || err.Code != errors.ErrCodeConflict {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreScoped(t *testing.T) {
+	blob, _ := newTestManager(context.Background(), &fakeOps{}).BackupSecret("db")
+	for _, scope := range []func(*KeyVaultClient){
+		func(kvc *KeyVaultClient) { kvc.nameAllowlist = []string{"app-*"} },
+		func(kvc *KeyVaultClient) { kvc.nameDenylist = []string{"db"} },
+	} {
+		f := &fakeOps{}
+		dst := newTestManager(context.Background(), f)
+		scope(dst.kvClient)
+		if _, err := dst.RestoreSecret(blob); err == nil || err.Code != errors.ErrCodeInsufficientAccess {
+			t.Fatal(err)
+		}
+		if len(f.backups) != 0 {
+			t.Fatal("restored despite the scope", f.backups)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// GetMany fetches the secrets with the given names using up to concurrency
+// parallel requests, and returns the secrets it fetched and the errors of
+// those it could not, by name. A failing name does not stop the others.
+//
+// When the client context is done, in-flight requests are aborted and the
+// names not fetched by then are reported with the context error, alongside
+// the secrets already gathered. GetMany returns only once all its goroutines
+// have exited.
+func (ksm *KeyVaultSecretsManager) GetMany(names []string, concurrency int) (map[string]*Secret, map[string]*errors.Error) {
+	return ksm.getMany(ksm.kvClient.ctx, names, concurrency)
+}
+
+// getMany is GetMany under the given context.
+func (ksm *KeyVaultSecretsManager) getMany(ctx context.Context, names []string, concurrency int) (map[string]*Secret, map[string]*errors.Error) {
+	start := ksm.kvClient.now()
+	secrets := make(map[string]*Secret, len(names))
+	errs := make(map[string]*errors.Error)
+
+	var mu sync.Mutex
+	sent := fanOut(ctx, len(names), concurrency, func(i int) {
+		secret, err := ksm.get(ctx, names[i], "")
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs[names[i]] = err
+		} else {
+			secrets[names[i]] = secret
+		}
+	})
+
+	for _, name := range names[sent:] {
+		errs[name] = ksm.kvClient.operationError(ctx, opGetSecret, name, start, ksm.kvClient.azError(ctx.Err()))
+	}
+
+	return secrets, errs
+}
+
+// SetMany sets the given secrets using up to concurrency parallel requests,
+// and returns the errors of those it could not set, by name. A failing
+// secret, e.g. a throttled one, does not stop the others, and the secrets
+// already set are left as they are.
+//
+// When the client context is done, in-flight requests are aborted and the
+// secrets not set by then are reported with the context error. SetMany
+// returns only once all its goroutines have exited.
+func (ksm *KeyVaultSecretsManager) SetMany(secrets []Secret, concurrency int) map[string]*errors.Error {
+	ctx := ksm.kvClient.ctx
+	start := ksm.kvClient.now()
+	errs := make(map[string]*errors.Error)
+
+	var mu sync.Mutex
+	sent := fanOut(ctx, len(secrets), concurrency, func(i int) {
+		if err := ksm.SetContext(ctx, secrets[i]); err != nil {
+			mu.Lock()
+			errs[secrets[i].Name] = err
+			mu.Unlock()
+		}
+	})
+
+	for _, secret := range secrets[sent:] {
+		errs[secret.Name] = ksm.kvClient.operationError(ctx, opSetSecret, secret.Name, start, ksm.kvClient.azError(ctx.Err()))
+	}
+
+	return errs
+}
+
+// fanOut calls fn with the indexes from 0 to n-1 from up to concurrency
+// goroutines, and returns how many indexes it handed out before ctx was done.
+// It returns once all its goroutines have exited.
+func fanOut(ctx context.Context, n, concurrency int, fn func(i int)) int {
+	var wg sync.WaitGroup
+	work := make(chan int)
+
+	for range max(concurrency, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				fn(i)
+			}
+		}()
+	}
+
+	next := 0
+feed:
+	for ; next < n; next++ {
+		select {
+		case work <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	return next
+}
+
+// GetAll returns every secret of the vault the client is allowed to see, with
+// its value, fetching the values with up to concurrency parallel requests.
+// When some values cannot be fetched, the other secrets are returned along
+// with an error listing the failures, which carries the code of the first
+// one.
+func (ksm *KeyVaultSecretsManager) GetAll(ctx context.Context, concurrency int) ([]Secret, *errors.Error) {
+	var names []string
+	err := ksm.walkSecrets(ctx, func(secret Secret) bool {
+		names = append(names, secret.Name)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fetched, errs := ksm.getMany(ctx, names, concurrency)
+
+	secrets := make([]Secret, 0, len(fetched))
+	var first *errors.Error
+	var failures []string
+	for _, name := range names {
+		if secret, ok := fetched[name]; ok {
+			secrets = append(secrets, *secret)
+			continue
+		}
+		if first == nil {
+			first = errs[name]
+		}
+		failures = append(failures, errs[name].Message)
+	}
+	if first == nil {
+		return secrets, nil
+	}
+
+	e := *first
+	e.Message = fmt.Sprintf("failed to fetch %d of %d secrets: %s", len(failures), len(names), strings.Join(failures, "; "))
+	return secrets, &e
+}
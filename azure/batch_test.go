@@ -0,0 +1,94 @@
+package azure
+
+import (
+	"context"
+	stdErrors "errors"
+	goruntime "runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestGetManyCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	n := 0
+	f := &fakeOps{getSecret: func(c context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		mu.Lock()
+		n++
+		k := n
+		mu.Unlock()
+		if k == 3 {
+			cancel()
+		}
+		if k >= 3 {
+			<-c.Done()
+			return azsecrets.GetSecretResponse{}, c.Err()
+		}
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp(name)}}, nil
+	}}
+	before := goruntime.NumGoroutine()
+	s, e := newTestManager(ctx, f).GetMany([]string{"a", "b", "c", "d", "e", "f"}, 1)
+	if len(s) != 2 || len(e) != 4 {
+		t.Fatal(s, e)
+	}
+	for name, err := range e {
+		if err.Code != errors.ErrCodeCanceled || !stdErrors.Is(err, ErrCanceled) {
+			t.Fatal(name, err)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	if goruntime.NumGoroutine() > before {
+		t.Fatal("leak")
+	}
+	s, e = newTestManager(context.Background(), &fakeOps{getSecret: func(c context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{}, nil
+	}}).GetMany([]string{"a", "b", "c"}, 8)
+	if len(s) != 3 || len(e) != 0 {
+		t.Fatal(s, e)
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{props("a", "b", "c")}, getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		if name == "b" {
+			return azsecrets.GetSecretResponse{}, respErr(403, `{"error":{"message":"denied"}}`, nil)
+		}
+		v := "val-" + name
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: &v}}, nil
+	}}
+	secrets, err := newTestManager(context.Background(), f).GetAll(context.Background(), 2)
+	if len(secrets) != 2 || secrets[0].Value != "val-a" || secrets[1].Name != "c" || err == nil || err.Code != errors.ErrCodeInsufficientAccess || !strings.Contains(err.Message, `GetSecret "b": denied`) {
+		t.Fatal(secrets, err)
+	}
+}
+
+func TestSetMany(t *testing.T) {
+	var mu sync.Mutex
+	set := map[string]bool{}
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		if name == "t" {
+			return azsecrets.SetSecretResponse{}, respErr(429, "", map[string]string{"Retry-After": "1"})
+		}
+		mu.Lock()
+		set[name] = true
+		mu.Unlock()
+		return azsecrets.SetSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	errs := ksm.SetMany([]Secret{{Name: "a"}, {Name: "t"}, {Name: "b"}}, 2)
+	if len(errs) != 1 || errs["t"].Code != errors.ErrCodeThrottled || !set["a"] || !set["b"] {
+		t.Fatal(errs, set)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs = newTestManager(ctx, f).SetMany([]Secret{{Name: "c"}, {Name: "d"}}, 1)
+	if len(errs) != 2 {
+		t.Fatal(errs)
+	}
+}
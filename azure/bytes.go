@@ -0,0 +1,41 @@
+package azure
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// SetBytes sets the secret with the given name to data, expiring ttl from
+// now, or never when ttl is zero, as SetValue does.
+//
+// KeyVault stores values as strings and the bytes are passed through as
+// UTF-8 text, not base64: a PEM bundle or a base64 blob is stored as is and
+// reads the same from any client. Data that is not valid UTF-8 would be
+// altered on the way, so it is a Validation error; encode binary data, e.g.
+// to base64, first.
+func (ksm *KeyVaultSecretsManager) SetBytes(name string, data []byte, ttl time.Duration) *errors.Error {
+	if !utf8.Valid(data) {
+		return errors.ValidationError(fmt.Sprintf("secret %q is not valid UTF-8, encode binary data before storing it", name))
+	}
+
+	return ksm.SetValue(name, string(data), ttl)
+}
+
+// GetBytes returns the value of the latest version of the secret with the
+// given name as bytes, the UTF-8 encoding of the stored string. Unlike Get,
+// it returns values that are not valid UTF-8 too, whatever
+// WithInvalidUTF8Mode says. A secret without a value is a NotFound error.
+func (ksm *KeyVaultSecretsManager) GetBytes(name string) ([]byte, *errors.Error) {
+	resp, err := ksm.readSecret(ksm.kvClient.ctx, name, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.Value == nil {
+		return nil, errors.NotFoundError(fmt.Sprintf("secret %q has no value", name))
+	}
+
+	return []byte(*resp.Value), nil
+}
@@ -0,0 +1,28 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestBytes(t *testing.T) {
+	f := vaultFake()
+	ksm := newTestManager(context.Background(), f)
+	data := []byte("-----BEGIN-----\nhéllo ✓ 日本\n-----END-----\n")
+	if err := ksm.SetBytes("pem", data, 0); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ksm.GetBytes("pem")
+	if err != nil || !bytes.Equal(got, data) {
+		t.Fatal(got, err)
+	}
+	if err := ksm.SetBytes("bin", []byte{0xff, 0xfe}, 0); err == nil || err.Code != errors.ErrCodeValidation {
+		t.Fatal(err)
+	}
+	if _, err := ksm.GetBytes("missing"); err == nil || err.Code != errors.ErrCodeNotFound {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,197 @@
+package azure
+
+import (
+	"sync"
+	"time"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// CachedSecrets decorates an IKeyVaultSecret with an in-memory cache of Get
+// results. Entries expire after their TTL and are invalidated by Set and
+// Delete through the same CachedSecrets. It is safe for concurrent use.
+type CachedSecrets struct {
+	inner IKeyVaultSecret
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu          sync.Mutex
+	entries     map[string]cacheEntry
+	ttls        map[string]time.Duration
+	notFoundTTL time.Duration
+	// generations counts the invalidations of each name, so that a refresh
+	// racing with a Set or a Delete does not cache what it read before.
+	generations map[string]uint64
+}
+
+// cacheEntry is a cached secret, or a cached NotFound error when notFound is
+// set.
+type cacheEntry struct {
+	secret   Secret
+	notFound *errors.Error
+	fetched  time.Time
+	expires  time.Time
+}
+
+// result returns the secret or the error the entry holds, as copies.
+func (e cacheEntry) result() (*Secret, *errors.Error) {
+	if e.notFound != nil {
+		err := *e.notFound
+		return nil, &err
+	}
+	secret := e.secret.clone()
+	return &secret, nil
+}
+
+var _ IKeyVaultSecret = (*CachedSecrets)(nil)
+
+// NewCachedSecrets returns a CachedSecrets caching the secrets read from inner
+// for ttl.
+func NewCachedSecrets(inner IKeyVaultSecret, ttl time.Duration) *CachedSecrets {
+	return &CachedSecrets{
+		inner:       inner,
+		ttl:         ttl,
+		now:         time.Now,
+		entries:     make(map[string]cacheEntry),
+		ttls:        make(map[string]time.Duration),
+		generations: make(map[string]uint64),
+	}
+}
+
+// SetTTL overrides the TTL of the secret with the given name, e.g. to keep a
+// frequently rotated secret fresher than the rest. A cached entry keeps the
+// TTL it was stored with until it is refreshed.
+func (cs *CachedSecrets) SetTTL(name string, ttl time.Duration) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.ttls[name] = ttl
+}
+
+// SetNotFoundTTL makes Get cache NotFound errors for ttl, to spare the vault
+// lookups of secrets that are known to be optional. A Set of the name
+// invalidates the entry as usual. By default, and with a zero ttl, no error is
+// cached.
+func (cs *CachedSecrets) SetNotFoundTTL(ttl time.Duration) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.notFoundTTL = ttl
+}
+
+// ttlFor returns the TTL of the named secret. cs.mu must be held.
+func (cs *CachedSecrets) ttlFor(name string) time.Duration {
+	if ttl, ok := cs.ttls[name]; ok {
+		return ttl
+	}
+	return cs.ttl
+}
+
+// List is not cached.
+func (cs *CachedSecrets) List() ([]Secret, *errors.Error) {
+	return cs.inner.List()
+}
+
+// Get returns the cached secret when it has not expired, and reads it from
+// the inner IKeyVaultSecret otherwise. Errors are not cached, except for
+// NotFound errors after SetNotFoundTTL. The returned secret is a copy that
+// callers may modify without affecting the cache.
+func (cs *CachedSecrets) Get(name string) (*Secret, *errors.Error) {
+	cs.mu.Lock()
+	entry, ok := cs.entries[name]
+	cs.mu.Unlock()
+	if ok && cs.now().Before(entry.expires) {
+		return entry.result()
+	}
+
+	return cs.refresh(name)
+}
+
+// GetFresh returns the cached secret only when it was fetched less than
+// maxStale ago, whatever its TTL, and reads it from the inner IKeyVaultSecret
+// otherwise. A failed read returns the error, not the stale secret.
+func (cs *CachedSecrets) GetFresh(name string, maxStale time.Duration) (*Secret, *errors.Error) {
+	cs.mu.Lock()
+	entry, ok := cs.entries[name]
+	cs.mu.Unlock()
+	if ok && cs.now().Sub(entry.fetched) < maxStale {
+		return entry.result()
+	}
+
+	return cs.refresh(name)
+}
+
+// refresh reads the secret from the inner IKeyVaultSecret and caches it, or
+// the NotFound error when those are cached. Nothing is cached when the name
+// was invalidated during the read.
+func (cs *CachedSecrets) refresh(name string) (*Secret, *errors.Error) {
+	cs.mu.Lock()
+	generation := cs.generations[name]
+	cs.mu.Unlock()
+
+	secret, err := cs.inner.Get(name)
+	if err != nil {
+		if err.Code == errors.ErrCodeNotFound {
+			cs.cacheNotFound(name, generation, err)
+		}
+		return nil, err
+	}
+
+	now := cs.now()
+	cs.mu.Lock()
+	if cs.generations[name] == generation {
+		cs.entries[name] = cacheEntry{secret: secret.clone(), fetched: now, expires: now.Add(cs.ttlFor(name))}
+	}
+	cs.mu.Unlock()
+
+	return secret, nil
+}
+
+// cacheNotFound caches the NotFound error err for the secret with the given
+// name, read at the given generation, when SetNotFoundTTL enabled it.
+func (cs *CachedSecrets) cacheNotFound(name string, generation uint64, err *errors.Error) {
+	now := cs.now()
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.notFoundTTL <= 0 || cs.generations[name] != generation {
+		return
+	}
+	notFound := *err
+	cs.entries[name] = cacheEntry{notFound: &notFound, fetched: now, expires: now.Add(cs.notFoundTTL)}
+}
+
+// Exists is answered from the cache when the secret, or its absence, is
+// cached.
+func (cs *CachedSecrets) Exists(name string) (bool, *errors.Error) {
+	cs.mu.Lock()
+	entry, ok := cs.entries[name]
+	cs.mu.Unlock()
+	if ok && cs.now().Before(entry.expires) {
+		return entry.notFound == nil, nil
+	}
+
+	return cs.inner.Exists(name)
+}
+
+// Set writes through to the inner IKeyVaultSecret and invalidates the cached
+// entry.
+func (cs *CachedSecrets) Set(secret Secret) *errors.Error {
+	defer cs.invalidate(secret.Name)
+	return cs.inner.Set(secret)
+}
+
+// Delete deletes through the inner IKeyVaultSecret and invalidates the cached
+// entry.
+func (cs *CachedSecrets) Delete(name string) *errors.Error {
+	defer cs.invalidate(name)
+	return cs.inner.Delete(name)
+}
+
+func (cs *CachedSecrets) invalidate(name string) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	delete(cs.entries, name)
+	cs.generations[name]++
+}
@@ -0,0 +1,121 @@
+package azure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestCacheTTL(t *testing.T) {
+	m := newCountingStore(Secret{Name: "a", Value: "1"}, Secret{Name: "b", Value: "2"})
+	cs := NewCachedSecrets(m, time.Minute)
+	now := time.Unix(0, 0)
+	cs.now = func() time.Time { return now }
+	cs.SetTTL("b", 10*time.Second)
+	cs.Get("a")
+	cs.Get("b")
+	now = now.Add(11 * time.Second)
+	cs.Get("a")
+	cs.Get("b")
+	if m.gets["a"] != 1 || m.gets["b"] != 2 {
+		t.Fatal(m.gets)
+	}
+	cs.Set(Secret{Name: "a", Value: "x"})
+	if s, _ := cs.Get("a"); s.Value != "x" {
+		t.Fatal(s)
+	}
+}
+
+func TestGetFresh(t *testing.T) {
+	m := newCountingStore(Secret{Name: "a", Value: "1"})
+	cs := NewCachedSecrets(m, time.Hour)
+	now := time.Unix(0, 0)
+	cs.now = func() time.Time { return now }
+	cs.Get("a")
+	now = now.Add(5 * time.Second)
+	cs.GetFresh("a", 10*time.Second)
+	if m.gets["a"] != 1 {
+		t.Fatal()
+	}
+	cs.GetFresh("a", time.Second)
+	if m.gets["a"] != 2 {
+		t.Fatal()
+	}
+	m.Delete("a")
+	if _, err := cs.GetFresh("a", 0); err == nil {
+		t.Fatal()
+	}
+}
+
+func TestCacheNotFound(t *testing.T) {
+	m := newCountingStore(Secret{Name: "a", Value: "1"})
+	cs := NewCachedSecrets(m, time.Minute)
+	now := time.Unix(0, 0)
+	cs.now = func() time.Time { return now }
+	cs.Get("a")
+	if s, _ := cs.Get("a"); s.Value != "1" || m.gets["a"] != 1 {
+		t.Fatal(m.gets)
+	}
+	cs.Get("x")
+	cs.Get("x")
+	if m.gets["x"] != 2 {
+		t.Fatal(m.gets)
+	}
+	cs.SetNotFoundTTL(10 * time.Second)
+	cs.Get("x")
+	if _, err := cs.Get("x"); err == nil || err.Code != errors.ErrCodeNotFound || m.gets["x"] != 3 {
+		t.Fatal(err, m.gets)
+	}
+	if ok, _ := cs.Exists("x"); ok {
+		t.Fatal()
+	}
+	cs.Set(Secret{Name: "x", Value: "2"})
+	if s, err := cs.Get("x"); err != nil || s.Value != "2" {
+		t.Fatal(err)
+	}
+	cs.Delete("x")
+	cs.Get("x")
+	now = now.Add(11 * time.Second)
+	cs.Get("x")
+	if m.gets["x"] != 6 {
+		t.Fatal(m.gets)
+	}
+}
+
+// racingStore lets a Set run while a Get is in flight.
+type racingStore struct {
+	*MemoryStore
+	during func()
+}
+
+func (r *racingStore) Get(name string) (*Secret, *errors.Error) {
+	s, err := r.MemoryStore.Get(name)
+	if r.during != nil {
+		during := r.during
+		r.during = nil
+		during()
+	}
+	return s, err
+}
+
+func TestCacheRefreshRace(t *testing.T) {
+	inner := &racingStore{MemoryStore: NewMemoryStore(Secret{Name: "a", Value: "old"})}
+	cs := NewCachedSecrets(inner, time.Minute)
+	inner.during = func() { cs.Set(Secret{Name: "a", Value: "new"}) }
+	if s, _ := cs.Get("a"); s.Value != "old" {
+		t.Fatal(s)
+	}
+	if s, _ := cs.Get("a"); s.Value != "new" {
+		t.Fatal("stale value cached:", s)
+	}
+
+	cs.SetNotFoundTTL(time.Minute)
+	inner.during = func() { cs.Set(Secret{Name: "b", Value: "new"}) }
+	if _, err := cs.Get("b"); err == nil {
+		t.Fatal("expected NotFound")
+	}
+	if s, err := cs.Get("b"); err != nil || s.Value != "new" {
+		t.Fatal("stale NotFound cached:", s, err)
+	}
+}
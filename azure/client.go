@@ -0,0 +1,239 @@
+// Package azure wraps the Azure SDK for Go to perform KeyVault operations
+// with a small, mockable API and a single error type.
+package azure
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/tracing"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// AzCredentialProvider creates the token credential used to authenticate
+// against Azure.
+type AzCredentialProvider interface {
+	NewDefaultAzureCredential(options *azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error)
+}
+
+// AzManagedIdentityCredentialProvider is implemented by the credential
+// providers that can also create managed identity credentials, for
+// WithManagedIdentity. Providers that do not implement it fall back to
+// azidentity.
+type AzManagedIdentityCredentialProvider interface {
+	NewManagedIdentityCredential(options *azidentity.ManagedIdentityCredentialOptions) (azcore.TokenCredential, error)
+}
+
+type defaultAzCredentialProvider struct{}
+
+func (defaultAzCredentialProvider) NewDefaultAzureCredential(options *azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
+	return azidentity.NewDefaultAzureCredential(options)
+}
+
+func (defaultAzCredentialProvider) NewManagedIdentityCredential(options *azidentity.ManagedIdentityCredentialOptions) (azcore.TokenCredential, error) {
+	return azidentity.NewManagedIdentityCredential(options)
+}
+
+// Client holds the Azure credential shared by the service clients of this
+// package.
+type Client struct {
+	credProvider    AzCredentialProvider
+	credential      azcore.TokenCredential
+	managedIdentity *azidentity.ManagedIdentityCredentialOptions
+	cloud           Cloud
+	options         azcore.ClientOptions
+	apiVersion      string
+	skipChallenge   bool
+	logger          *slog.Logger
+	rateLimiter     *rateLimiter
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithCredential makes the Client authenticate with credential, e.g. a
+// client secret or workload identity credential, instead of building the
+// DefaultAzureCredential chain and its probing.
+func WithCredential(credential azcore.TokenCredential) ClientOption {
+	return func(c *Client) {
+		c.credential = credential
+	}
+}
+
+// WithManagedIdentity makes the Client authenticate as the user-assigned
+// managed identity with the given client id, or as the system-assigned one
+// when clientID is empty, instead of building the DefaultAzureCredential
+// chain. WithCredential takes precedence over it.
+func WithManagedIdentity(clientID string) ClientOption {
+	return func(c *Client) {
+		c.managedIdentity = &azidentity.ManagedIdentityCredentialOptions{}
+		if clientID != "" {
+			c.managedIdentity.ID = azidentity.ClientID(clientID)
+		}
+	}
+}
+
+// WithCloud makes the Client authenticate against the authority host of
+// cloud, and the KeyVaultClients created from it address their vaults under
+// its DNS suffix, e.g. AzureGovernment. A credential given with
+// WithCredential must be configured for the cloud by the caller. The default
+// is AzurePublic.
+func WithCloud(cloud Cloud) ClientOption {
+	return func(c *Client) {
+		c.cloud = cloud
+	}
+}
+
+// WithRetry configures the retries of the Azure SDK pipeline, for the
+// credential and the vaults: up to maxRetries retries of a failed request,
+// with an exponential backoff starting at baseDelay. A maxRetries of zero
+// disables them, for latency-sensitive callers; a zero baseDelay keeps the
+// SDK default. They are independent of WithRetryPolicy, which retries whole
+// operations.
+func WithRetry(maxRetries int, baseDelay time.Duration) ClientOption {
+	return func(c *Client) {
+		c.options.Retry.MaxRetries = int32(maxRetries)
+		if maxRetries == 0 {
+			// Zero selects the SDK default; a negative value disables retries.
+			c.options.Retry.MaxRetries = -1
+		}
+		c.options.Retry.RetryDelay = baseDelay
+	}
+}
+
+// WithHTTPClient sends the requests of the credential and the vaults through
+// httpClient, e.g. one with a corporate proxy, custom TLS roots or a
+// transport intercepting requests in tests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.options.Transport = httpClient
+	}
+}
+
+// WithApplicationID prepends appID, e.g. "payments/1.4.2", to the User-Agent
+// of the requests of the credential and the vaults, so that KeyVault telemetry
+// can be attributed to the application. The SDK truncates it to 24
+// characters and replaces spaces with slashes.
+func WithApplicationID(appID string) ClientOption {
+	return func(c *Client) {
+		c.options.Telemetry.ApplicationID = appID
+	}
+}
+
+// WithAPIVersion pins the KeyVault service API version the vaults are called
+// with, e.g. "7.4", instead of DefaultAPIVersion. The credential is not
+// affected.
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+// WithInsecureSkipChallengeResourceVerification stops the vaults from
+// checking that the resource of the authentication challenge matches the
+// vault host, for API management gateways and proxies whose challenge names
+// another resource. It weakens a security check: a host that is not the vault
+// can then obtain tokens for it, so only enable it for hosts you control.
+func WithInsecureSkipChallengeResourceVerification(skip bool) ClientOption {
+	return func(c *Client) {
+		c.skipChallenge = skip
+	}
+}
+
+// WithTracingProvider records the operations of the vaults as spans from
+// provider, around those the Azure SDK records for their requests. Spans carry
+// the vault and secret names, never values. Tracing is off by default.
+//
+// OpenTelemetry tracers are adapted with the azotel module,
+// github.com/Azure/azure-sdk-for-go/sdk/tracing/azotel:
+//
+//	azure.WithTracingProvider(azotel.NewTracingProvider(otel.GetTracerProvider(), nil))
+func WithTracingProvider(provider tracing.Provider) ClientOption {
+	return func(c *Client) {
+		c.options.TracingProvider = provider
+	}
+}
+
+// WithLogger makes the vaults log every List, Get, Set, Delete and Rotate to
+// logger at debug level, with the vault, the secret name, the duration and
+// the outcome. Values are never logged. Nothing is logged by default.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithRateLimit caps the requests sent to the vaults at rps per second, with
+// bursts of up to rps requests, to stay below the throttling limits of
+// KeyVault during bulk operations. Requests over the budget wait for it,
+// unless their context is done first. The limit is shared by every vault of
+// the Client and does not apply to the credential. A non-positive rps
+// disables it, which is the default.
+func WithRateLimit(rps int) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = nil
+		if rps > 0 {
+			c.rateLimiter = newRateLimiter(rps)
+		}
+	}
+}
+
+// NewClient creates a Client authenticated through the DefaultAzureCredential
+// chain (environment, workload identity, managed identity, Azure CLI, ...),
+// unless an option sets another credential.
+func NewClient(opts ...ClientOption) (*Client, *errors.Error) {
+	return newClient(defaultAzCredentialProvider{}, opts...)
+}
+
+func newClient(credProvider AzCredentialProvider, opts ...ClientOption) (*Client, *errors.Error) {
+	client := &Client{credProvider: credProvider, cloud: AzurePublic}
+	for _, opt := range opts {
+		opt(client)
+	}
+	client.options.Cloud = client.cloud.Configuration
+	if client.credential != nil {
+		return client, nil
+	}
+
+	if client.managedIdentity != nil {
+		miProvider, ok := credProvider.(AzManagedIdentityCredentialProvider)
+		if !ok {
+			miProvider = defaultAzCredentialProvider{}
+		}
+		client.managedIdentity.ClientOptions = client.options
+		credential, err := miProvider.NewManagedIdentityCredential(client.managedIdentity)
+		if err != nil {
+			return nil, errors.UnauthorizedError(fmt.Sprintf("failed to create managed identity credential: %v", err))
+		}
+		client.credential = credential
+		return client, nil
+	}
+
+	credential, err := credProvider.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{ClientOptions: client.options})
+	if err != nil {
+		return nil, errors.UnauthorizedError(fmt.Sprintf("failed to create azure credential: %v", err))
+	}
+	client.credential = credential
+
+	return client, nil
+}
+
+// secretsClientOptions returns the options of the azsecrets clients created
+// with the credential of c.
+func (c *Client) secretsClientOptions(insecure bool) *azsecrets.ClientOptions {
+	options := &azsecrets.ClientOptions{ClientOptions: c.options}
+	options.InsecureAllowCredentialWithHTTP = insecure
+	options.APIVersion = c.apiVersion
+	options.DisableChallengeResourceVerification = c.skipChallenge
+	if c.rateLimiter != nil {
+		options.PerRetryPolicies = append(slices.Clip(options.PerRetryPolicies), c.rateLimiter)
+	}
+	return options
+}
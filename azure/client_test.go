@@ -0,0 +1,116 @@
+package azure
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+func TestWithCredential(t *testing.T) {
+	p := &fakeProvider{cred: &fakeCred{}}
+	mine := &fakeCred{}
+	c, err := newClient(p, WithCredential(mine))
+	if err != nil || c.credential != mine || p.defaults != 0 {
+		t.Fatal(err, p.defaults)
+	}
+	c, _ = newClient(p)
+	if c.credential != p.cred || p.defaults != 1 {
+		t.Fatal(p.defaults)
+	}
+}
+
+func TestManagedIdentity(t *testing.T) {
+	p := &fakeMIProvider{fakeProvider: fakeProvider{cred: &fakeCred{}}}
+	c, err := newClient(p, WithManagedIdentity("abc"))
+	if err != nil || c.credential != p.cred || p.got.ID != azidentity.ClientID("abc") || p.defaults != 0 {
+		t.Fatal(err, p.got)
+	}
+	newClient(p, WithManagedIdentity(""))
+	if p.got.ID != nil {
+		t.Fatal(p.got.ID)
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	p := &fakeMIProvider{fakeProvider: fakeProvider{cred: &fakeCred{}}}
+	c, _ := newClient(p, WithRetry(5, time.Second), WithManagedIdentity(""))
+	if o := c.secretsClientOptions(false); o.Retry.MaxRetries != 5 || o.Retry.RetryDelay != time.Second || p.got.Retry.MaxRetries != 5 {
+		t.Fatal(o.Retry)
+	}
+	c, _ = newClient(p, WithRetry(0, 0))
+	if o := c.secretsClientOptions(false); o.Retry.MaxRetries != -1 {
+		t.Fatal(o.Retry)
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	var hosts []string
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		hosts = append(hosts, r.URL.Host)
+		return &http.Response{StatusCode: 200, Header: http.Header{"Content-Type": {"application/json"}}, Body: io.NopCloser(strings.NewReader(`{"value":"s3cr3t","id":"https://vlt.vault.azure.net/secrets/db/v1"}`)), Request: r}, nil
+	})}
+	p := &fakeProvider{cred: &fakeCred{}}
+	c, _ := newClient(p, WithHTTPClient(hc))
+	kvc, err := NewKeyVaultClient(context.Background(), c, "vlt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewKeyVaultSecretsManager(kvc).Get("db")
+	if err != nil || s.Value != "s3cr3t" || len(hosts) == 0 || hosts[0] != "vlt.vault.azure.net" {
+		t.Fatal(s, err, hosts)
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	var versions []string
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		versions = append(versions, r.URL.Query().Get("api-version"))
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"value":"x","id":"https://vlt.vault.azure.net/secrets/db/v1"}`)), Request: r}, nil
+	})}
+	c, _ := newClient(&fakeProvider{cred: &fakeCred{}}, WithHTTPClient(hc), WithAPIVersion("7.4"))
+	if c.secretsClientOptions(false).APIVersion != "7.4" {
+		t.Fatal()
+	}
+	kvc, _ := NewKeyVaultClient(context.Background(), c, "vlt")
+	NewKeyVaultSecretsManager(kvc).Get("db")
+	if len(versions) == 0 || versions[0] != "7.4" || kvc.ClientInfo().APIVersion != "7.4" {
+		t.Fatal(versions)
+	}
+	kvc, _ = NewKeyVaultClient(context.Background(), &Client{credential: &fakeCred{}}, "vlt")
+	if kvc.ClientInfo().APIVersion != DefaultAPIVersion {
+		t.Fatal()
+	}
+}
+
+func TestApplicationID(t *testing.T) {
+	var ua string
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		ua = r.Header.Get("User-Agent")
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"value":"x","id":"https://vlt.vault.azure.net/secrets/db/v1"}`)), Request: r}, nil
+	})}
+	c, _ := newClient(&fakeProvider{cred: &fakeCred{}}, WithHTTPClient(hc), WithApplicationID("payments/1.4"))
+	if c.secretsClientOptions(false).Telemetry.ApplicationID != "payments/1.4" {
+		t.Fatal()
+	}
+	kvc, _ := NewKeyVaultClient(context.Background(), c, "vlt")
+	NewKeyVaultSecretsManager(kvc).Get("db")
+	if !strings.HasPrefix(ua, "payments/1.4 azsdk-go-") {
+		t.Fatal(ua)
+	}
+}
+
+func TestSkipChallenge(t *testing.T) {
+	c, _ := newClient(&fakeProvider{cred: &fakeCred{}})
+	if c.secretsClientOptions(false).DisableChallengeResourceVerification {
+		t.Fatal()
+	}
+	c, _ = newClient(&fakeProvider{cred: &fakeCred{}}, WithInsecureSkipChallengeResourceVerification(true))
+	if !c.secretsClientOptions(false).DisableChallengeResourceVerification {
+		t.Fatal()
+	}
+}
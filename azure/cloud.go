@@ -0,0 +1,36 @@
+package azure
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+// Cloud is an Azure cloud: where its vaults live and where its identities
+// authenticate.
+type Cloud struct {
+	// VaultDNSSuffix is the domain vault names are prefixed to, e.g.
+	// "vault.azure.net".
+	VaultDNSSuffix string
+	// Configuration holds the authority host credentials authenticate
+	// against.
+	Configuration cloud.Configuration
+}
+
+// The Azure clouds with KeyVault. Azure Germany was retired in 2021; its
+// vaults were migrated to AzurePublic.
+var (
+	AzurePublic     = Cloud{VaultDNSSuffix: "vault.azure.net", Configuration: cloud.AzurePublic}
+	AzureGovernment = Cloud{VaultDNSSuffix: "vault.usgovcloudapi.net", Configuration: cloud.AzureGovernment}
+	AzureChina      = Cloud{VaultDNSSuffix: "vault.azure.cn", Configuration: cloud.AzureChina}
+)
+
+// vaultURL returns the URL of the vault with the given name in the cloud.
+func (c Cloud) vaultURL(vaultName string) string {
+	return fmt.Sprintf("https://%s.%s", vaultName, c.VaultDNSSuffix)
+}
+
+// scope returns the token scope of the vaults of the cloud.
+func (c Cloud) scope() string {
+	return fmt.Sprintf("https://%s/.default", c.VaultDNSSuffix)
+}
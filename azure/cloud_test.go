@@ -0,0 +1,22 @@
+package azure
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCloud(t *testing.T) {
+	p := &fakeMIProvider{fakeProvider: fakeProvider{cred: &fakeCred{}}}
+	c, err := newClient(p, WithCloud(AzureGovernment), WithManagedIdentity(""))
+	if err != nil || p.got.Cloud.ActiveDirectoryAuthorityHost != "https://login.microsoftonline.us/" {
+		t.Fatal(err, p.got)
+	}
+	kvc, err := NewKeyVaultClient(context.Background(), c, "vlt")
+	if err != nil || kvc.vaultURL != "https://vlt.vault.usgovcloudapi.net" {
+		t.Fatal(err, kvc.vaultURL)
+	}
+	kvc, _ = NewKeyVaultClient(context.Background(), &Client{credential: &fakeCred{}}, "vlt")
+	if kvc.vaultURL != "https://vlt.vault.azure.net" {
+		t.Fatal(kvc.vaultURL)
+	}
+}
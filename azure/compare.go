@@ -0,0 +1,19 @@
+package azure
+
+import (
+	"maps"
+)
+
+// EqualIgnoringMeta reports whether a and b hold the same value and
+// settable attributes, ignoring what KeyVault assigns on write, so that a
+// desired secret can be compared with the one read back from the vault.
+// Empty and nil tags are equal, as are a nil and a true Enabled, and times are
+// compared as instants.
+func EqualIgnoringMeta(a, b Secret) bool {
+	return a.Value == b.Value &&
+		a.ContentType == b.ContentType &&
+		a.IsEnabled() == b.IsEnabled() &&
+		maps.Equal(a.Tags, b.Tags) &&
+		a.Expiration.Equal(b.Expiration) &&
+		a.NotBefore.Equal(b.NotBefore)
+}
@@ -0,0 +1,25 @@
+package azure
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	now := time.Now()
+	base := Secret{Name: "a", Value: "v", Expiration: now}
+	cases := []struct {
+		b  Secret
+		eq bool
+	}{
+		{Secret{Name: "a", Value: "v", Expiration: now.UTC(), Tags: map[string]string{}}, true},
+		{Secret{Name: "a", Value: "w", Expiration: now}, false},
+		{Secret{Name: "a", Value: "v"}, false},
+		{Secret{Name: "a", Value: "v", Expiration: now, Tags: map[string]string{"x": ""}}, false},
+	}
+	for i, c := range cases {
+		if EqualIgnoringMeta(base, c.b) != c.eq {
+			t.Fatal(i)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package azure
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// valueEncodingTag marks values stored in an encoding this package decodes
+// on read. It is hidden from the tags of the secrets returned to callers and
+// reserved: callers cannot set it, see checkReservedTags. The prefix keeps it
+// apart from tags of the callers' own.
+const valueEncodingTag = "x-kv-sdk-value-encoding"
+
+// gzipEncoding is the value of valueEncodingTag for values that are gzipped,
+// then base64 encoded since KeyVault stores strings.
+const gzipEncoding = "gzip+base64"
+
+// checkReservedTags rejects tags, the caller and default tags of a write, that
+// set the tag this package reserves to mark encoded values. Writing it would
+// make the value unreadable, or read back differently.
+func checkReservedTags(name string, tags map[string]string) *errors.Error {
+	if _, ok := tags[valueEncodingTag]; ok {
+		return errors.ValidationError(fmt.Sprintf("secret %q sets the tag %q, which is reserved", name, valueEncodingTag))
+	}
+	return nil
+}
+
+// compressValue returns the gzip+base64 encoding of value, and whether it is
+// shorter than value and so worth storing instead.
+func compressValue(value string) (string, bool) {
+	var buf bytes.Buffer
+	zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if _, err := io.WriteString(zw, value); err != nil {
+		return "", false
+	}
+	if err := zw.Close(); err != nil {
+		return "", false
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(buf.Bytes())
+	return encoded, len(encoded) < len(value)
+}
+
+// decodeValue replaces the value of resp with its decoded form when it was
+// stored compressed, whether or not compression is enabled on the client.
+func decodeValue(name string, resp *azsecrets.GetSecretResponse) *errors.Error {
+	encoding := resp.Tags[valueEncodingTag]
+	if encoding == nil || resp.Value == nil {
+		return nil
+	}
+	if *encoding != gzipEncoding {
+		return errors.InternalServerError(fmt.Sprintf("secret %q has unknown value encoding %q", name, *encoding))
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(*resp.Value)
+	if err != nil {
+		return errors.InternalServerError(fmt.Sprintf("failed to decode compressed secret %q: %v", name, err))
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return errors.InternalServerError(fmt.Sprintf("failed to decompress secret %q: %v", name, err))
+	}
+	value, err := io.ReadAll(zr)
+	if err != nil {
+		return errors.InternalServerError(fmt.Sprintf("failed to decompress secret %q: %v", name, err))
+	}
+
+	decoded := string(value)
+	resp.Value = &decoded
+	return nil
+}
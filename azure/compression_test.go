@@ -0,0 +1,60 @@
+package azure
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestCompression(t *testing.T) {
+	store := map[string]azsecrets.SetSecretParameters{}
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		store[name] = p
+		return azsecrets.SetSecretResponse{}, nil
+	}, getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		p := store[name]
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: p.Value, Tags: p.Tags}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.kvClient.compressValues = true
+	big := strings.Repeat(`{"k":"v"},`, 1000)
+	if err := ksm.Set(Secret{Name: "big", Value: big, Tags: map[string]string{"a": "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*store["big"].Value) >= len(big) || *store["big"].Tags[valueEncodingTag] != gzipEncoding {
+		t.Fatal("not compressed")
+	}
+	s, err := ksm.Get("big")
+	if err != nil || s.Value != big || len(s.Tags) != 1 {
+		t.Fatal(err, s.Tags)
+	}
+	_ = ksm.Set(Secret{Name: "small", Value: "x7#Q"})
+	if *store["small"].Value != "x7#Q" || store["small"].Tags != nil {
+		t.Fatal(store["small"])
+	}
+	s, _ = ksm.Get("small")
+	if s.Value != "x7#Q" || s.Tags != nil {
+		t.Fatal(s)
+	}
+}
+
+func TestReservedTag(t *testing.T) {
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		t.Fatal("unexpected write")
+		return azsecrets.SetSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	reserved := map[string]string{valueEncodingTag: gzipEncoding}
+	if err := ksm.Set(Secret{Name: "a", Value: "v", Tags: reserved}); err == nil || err.Code != "ValidationError" {
+		t.Fatal(err)
+	}
+	if err := ksm.UpdateProperties(Secret{Name: "a", Tags: reserved}); err == nil || err.Code != "ValidationError" {
+		t.Fatal(err)
+	}
+	ksm.kvClient.defaultTags = reserved
+	if err := ksm.Set(Secret{Name: "a", Value: "v"}); err == nil || err.Code != "ValidationError" {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,79 @@
+package azure
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// RecoverDeletedSecret restores the soft-deleted secret with the given name,
+// with all its versions. KeyVault recovers it asynchronously, so it can take
+// a few seconds before Get finds it. There being no deleted secret by that
+// name is a NotFound error.
+func (ksm *KeyVaultSecretsManager) RecoverDeletedSecret(name string) *errors.Error {
+	defer ksm.invalidateExistence(name)
+
+	vaultName, err := ksm.kvClient.vaultSecretName(name)
+	if err != nil {
+		return err
+	}
+
+	start := ksm.kvClient.now()
+	err = ksm.kvClient.retry(ksm.kvClient.ctx, func() *errors.Error {
+		if _, err := ksm.secretsClient.RecoverDeletedSecret(ksm.kvClient.ctx, vaultName, nil); err != nil {
+			return ksm.kvClient.azError(err)
+		}
+		return nil
+	})
+
+	return ksm.kvClient.operationError(ksm.kvClient.ctx, opRecoverDeletedSecret, name, start, err)
+}
+
+// ListDeletedSecrets returns the soft-deleted secrets of the vault that the
+// client is allowed to see, flagged as Deleted and with the dates they were
+// deleted on and are purged on. A vault without soft-delete returns the error
+// KeyVault gives for it.
+func (ksm *KeyVaultSecretsManager) ListDeletedSecrets() ([]Secret, *errors.Error) {
+	var secrets []Secret
+	err := ksm.walkDeletedSecrets(ksm.kvClient.ctx, func(secret Secret) bool {
+		secrets = append(secrets, secret)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// GetDeletedSecret returns the soft-deleted secret with the given name,
+// without its value, as ListDeletedSecrets does. There being no deleted
+// secret by that name is a NotFound error.
+func (ksm *KeyVaultSecretsManager) GetDeletedSecret(name string) (*Secret, *errors.Error) {
+	vaultName, err := ksm.kvClient.vaultSecretName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	start := ksm.kvClient.now()
+	var resp azsecrets.GetDeletedSecretResponse
+	err = ksm.kvClient.retry(ksm.kvClient.ctx, func() *errors.Error {
+		var err error
+		resp, err = ksm.secretsClient.GetDeletedSecret(ksm.kvClient.ctx, vaultName, nil)
+		if err != nil {
+			return ksm.kvClient.azError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, ksm.kvClient.operationError(ksm.kvClient.ctx, opGetDeletedSecret, name, start, err)
+	}
+
+	secret := &Secret{Name: name, Deleted: true, redaction: ksm.kvClient.redactionStyle}
+	applyAttributes(secret, resp.Attributes)
+	secret.ContentType = derefString(resp.ContentType)
+	secret.Tags = fromAzTags(resp.Tags)
+	applyDeletion(secret, resp.DeletedDate, resp.ScheduledPurgeDate)
+
+	return secret, nil
+}
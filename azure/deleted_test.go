@@ -0,0 +1,55 @@
+package azure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestRecover(t *testing.T) {
+	f := &fakeOps{recover: func(name string) (azsecrets.RecoverDeletedSecretResponse, error) {
+		if name == "gone" {
+			return azsecrets.RecoverDeletedSecretResponse{}, respErr(404, `{"error":{"message":"Deleted Secret not found"}}`, nil)
+		}
+		return azsecrets.RecoverDeletedSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	if err := ksm.RecoverDeletedSecret("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ksm.RecoverDeletedSecret("gone"); err == nil || err.Code != errors.ErrCodeNotFound {
+		t.Fatal(err)
+	}
+}
+
+func TestDeletedSecrets(t *testing.T) {
+	del, purge := time.Unix(1000, 0), time.Unix(2000, 0)
+	dp := dprops("x")
+	dp[0].DeletedDate, dp[0].ScheduledPurgeDate = &del, &purge
+	f := &fakeOps{deletedPages: [][]*azsecrets.DeletedSecretProperties{dp}, getDeleted: func(name string) (azsecrets.GetDeletedSecretResponse, error) {
+		if name != "x" {
+			return azsecrets.GetDeletedSecretResponse{}, respErr(404, "", nil)
+		}
+		return azsecrets.GetDeletedSecretResponse{DeletedSecret: azsecrets.DeletedSecret{DeletedDate: &del, ScheduledPurgeDate: &purge}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	l, err := ksm.ListDeletedSecrets()
+	if err != nil || len(l) != 1 || !l[0].Deleted || !l[0].ScheduledPurgeDate.Equal(purge) {
+		t.Fatal(l, err)
+	}
+	s, err := ksm.GetDeletedSecret("x")
+	if err != nil || !s.DeletedDate.Equal(del) {
+		t.Fatal(s, err)
+	}
+	if _, err := ksm.GetDeletedSecret("y"); err == nil || err.Code != errors.ErrCodeNotFound {
+		t.Fatal(err)
+	}
+	f.deletedErr = respErr(403, `{"error":{"message":"soft delete disabled"}}`, nil)
+	if _, err := ksm.ListDeletedSecrets(); err == nil || err.Status != 403 {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,181 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// Inner error codes KeyVault uses to tell why a request was forbidden.
+const (
+	innerCodeForbiddenByFirewall = "ForbiddenByFirewall"
+	innerCodeSecretDisabled      = "SecretDisabled"
+)
+
+// azErrorResponse is the error document KeyVault returns on failure.
+type azErrorResponse struct {
+	Error struct {
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+		InnerError *struct {
+			Code string `json:"code"`
+		} `json:"innererror"`
+	} `json:"error"`
+}
+
+// requestIDHeader carries the id KeyVault assigns to every request.
+const requestIDHeader = "x-ms-request-id"
+
+// Headers KeyVault tells how long to wait before retrying with, from the most
+// to the least precise.
+var retryAfterHeaders = []struct {
+	name string
+	unit time.Duration
+}{
+	{"retry-after-ms", time.Millisecond},
+	{"x-ms-retry-after-ms", time.Millisecond},
+	{"Retry-After", time.Second},
+}
+
+// azError maps an Azure SDK error with checkAzErrResp and applies the error
+// settings of the client.
+func (kvc *KeyVaultClient) azError(err error) *errors.Error {
+	e := checkAzErrResp(err)
+	if kvc.redactTraceID {
+		e.TraceId = ""
+	}
+	return e
+}
+
+// operationError adds the operation and the secret it targeted to err, e.g.
+// `GetSecret "db": Secret not found`, so that the failure explains itself in
+// logs. Timeout errors get the timing of the operation instead, e.g.
+// `GetSecret "db" exceeded its 30s deadline after 30.02s`. name is empty for
+// operations on the whole vault.
+func (kvc *KeyVaultClient) operationError(ctx context.Context, operation, name string, start time.Time, err *errors.Error) *errors.Error {
+	if err == nil {
+		return nil
+	}
+
+	target := kvc.operationName(operation)
+	if name != "" {
+		target = fmt.Sprintf("%s %q", target, name)
+	}
+	if err.Code != errors.ErrCodeTimeout {
+		err.Message = fmt.Sprintf("%s: %s", target, err.Message)
+		return err
+	}
+
+	elapsed := kvc.now().Sub(start).Round(time.Millisecond)
+	if deadline, ok := ctx.Deadline(); ok {
+		budget := deadline.Sub(start).Round(time.Millisecond)
+		err.Message = fmt.Sprintf("%s exceeded its %s deadline after %s: %s", target, budget, elapsed, err.Message)
+	} else {
+		err.Message = fmt.Sprintf("%s timed out after %s: %s", target, elapsed, err.Message)
+	}
+
+	return err
+}
+
+// checkAzErrResp converts an error returned by the Azure SDK into an
+// *errors.Error, using the message from the KeyVault error document when one
+// is present and the request id as TraceId. Context errors become Timeout and
+// Canceled errors.
+func checkAzErrResp(err error) *errors.Error {
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return errors.TimeoutError(err.Error()).WithCause(err)
+	}
+	if stderrors.Is(err, context.Canceled) {
+		return errors.CanceledError(err.Error()).WithCause(err)
+	}
+
+	var respErr *azcore.ResponseError
+	if !stderrors.As(err, &respErr) {
+		return errors.InternalServerError(err.Error()).WithCause(err)
+	}
+
+	// Transport failures can be wrapped in a ResponseError without a
+	// response, in which case only the error text is available.
+	message := respErr.Error()
+	innerCode := ""
+	if respErr.RawResponse != nil && respErr.RawResponse.Body != nil {
+		if body, readErr := io.ReadAll(respErr.RawResponse.Body); readErr == nil {
+			var azErr azErrorResponse
+			if json.Unmarshal(body, &azErr) == nil {
+				if azErr.Error.Message != "" {
+					message = azErr.Error.Message
+				}
+				if azErr.Error.InnerError != nil {
+					innerCode = azErr.Error.InnerError.Code
+				}
+			}
+		}
+	}
+
+	var e *errors.Error
+	switch respErr.StatusCode {
+	case http.StatusBadRequest:
+		e = errors.BadRequestError(message)
+	case http.StatusNotFound:
+		e = errors.NotFoundError(message)
+	case http.StatusUnauthorized:
+		e = errors.UnauthorizedError(message)
+	case http.StatusForbidden:
+		// ForbiddenByPolicy and ForbiddenByRbac both call for a permission
+		// fix; only a firewall denial calls for a network one. A disabled
+		// secret is not an access problem at all.
+		switch innerCode {
+		case innerCodeForbiddenByFirewall:
+			e = errors.ForbiddenByFirewallError(message)
+		case innerCodeSecretDisabled:
+			e = errors.SecretDisabledError(message)
+		default:
+			e = errors.InsufficientAccessError(message)
+		}
+	case http.StatusConflict:
+		e = errors.ConflictError(message)
+	case http.StatusTooManyRequests:
+		e = errors.ThrottledError(message)
+	case http.StatusBadGateway:
+		e = errors.BadGatewayError(message)
+	case http.StatusServiceUnavailable:
+		e = errors.ServiceUnavailableError(message)
+	default:
+		e = errors.InternalServerError(message)
+	}
+	if respErr.RawResponse != nil {
+		e.TraceId = respErr.RawResponse.Header.Get(requestIDHeader)
+		e.RetryAfter = retryAfter(respErr.RawResponse.Header)
+	}
+
+	return e.WithCause(err)
+}
+
+// retryAfter returns the delay the response headers ask to wait before
+// retrying, or zero. Retry-After can also be an HTTP date.
+func retryAfter(header http.Header) time.Duration {
+	for _, h := range retryAfterHeaders {
+		v := header.Get(h.name)
+		if v == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return time.Duration(n) * h.unit
+		}
+		if h.unit == time.Second {
+			if at, err := http.ParseTime(v); err == nil {
+				return max(time.Until(at), 0)
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,143 @@
+package azure
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestTraceRedact(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{}, respErr(404, "", map[string]string{"x-ms-request-id": "rid"})
+	}}
+	ksm := newTestManager(context.Background(), f)
+	if _, err := ksm.Get("a"); err.TraceId != "rid" {
+		t.Fatal(err)
+	}
+	ksm.kvClient.redactTraceID = true
+	if _, err := ksm.Get("a"); err.TraceId != "" {
+		t.Fatal(err)
+	}
+}
+
+func Test5xx(t *testing.T) {
+	a, b, c := checkAzErrResp(respErr(500, "", nil)), checkAzErrResp(respErr(502, "", nil)), checkAzErrResp(respErr(503, "", nil))
+	if a.IsTransient() || !b.IsTransient() || !c.IsTransient() || a.Code == b.Code || b.Code == c.Code || c.Status != 503 {
+		t.Fatal(a, b, c)
+	}
+}
+
+func TestNilRaw(t *testing.T) {
+	e := checkAzErrResp(&azcore.ResponseError{ErrorCode: "X"})
+	if e.Code != "InternalServerError" || e.Message == "" {
+		t.Fatal(e)
+	}
+	e = checkAzErrResp(&azcore.ResponseError{StatusCode: 503})
+	if e.Code != "ServiceUnavailable" {
+		t.Fatal(e)
+	}
+}
+
+func TestFirewall(t *testing.T) {
+	e := checkAzErrResp(respErr(403, `{"error":{"code":"Forbidden","message":"Client address is not authorized","innererror":{"code":"ForbiddenByFirewall"}}}`, nil))
+	p := checkAzErrResp(respErr(403, `{"error":{"code":"Forbidden","message":"no policy","innererror":{"code":"ForbiddenByPolicy"}}}`, nil))
+	if e.Code != "ForbiddenByFirewall" || p.Code != "InsufficientAccess" || p.Message != "no policy" {
+		t.Fatal(e, p)
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		<-ctx.Done()
+		return azsecrets.GetSecretResponse{}, ctx.Err()
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	ksm := newTestManager(ctx, f)
+	_, err := ksm.Get("a")
+	t.Log(err)
+	if err == nil || err.Code != errors.ErrCodeTimeout || !strings.Contains(err.Message, `GetSecret "a" exceeded its `) || !strings.Contains(err.Message, "ms deadline after") {
+		t.Fatal(err)
+	}
+}
+
+func TestCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e := checkAzErrResp(fmt.Errorf("send: %w", ctx.Err()))
+	if e.Code != errors.ErrCodeCanceled || e.Status != 499 || e.IsTransient() || !stdErrors.Is(e, context.Canceled) {
+		t.Fatal(e)
+	}
+}
+
+func TestBadRequest(t *testing.T) {
+	e := checkAzErrResp(respErr(400, `{"error":{"code":"BadParameter","message":"The request URI contains an invalid name: a_b"}}`, nil))
+	if e.Code != errors.ErrCodeBadRequest || e.Status != 400 || !strings.Contains(e.Message, "invalid name") {
+		t.Fatal(e)
+	}
+}
+
+func TestOperationContext(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{}, respErr(404, `{"error":{"message":"Secret not found: db"}}`, nil)
+	}, deleteSecret: func(ctx context.Context, name string) (azsecrets.DeleteSecretResponse, error) {
+		return azsecrets.DeleteSecretResponse{}, respErr(403, `{"error":{"message":"denied"}}`, nil)
+	}, listErr: respErr(503, "", nil)}
+	ksm := newTestManager(context.Background(), f)
+	ksm.kvClient.operationPrefix = "app."
+	if _, err := ksm.Get("db"); err == nil || err.Message != `app.GetSecret "db": Secret not found: db` {
+		t.Fatal(err)
+	}
+	if err := ksm.Delete("db"); err == nil || err.Message != `app.DeleteSecret "db": denied` {
+		t.Fatal(err)
+	}
+	if _, err := ksm.List(); err == nil || !strings.HasPrefix(err.Message, "app.ListSecrets: ") {
+		t.Fatal(err)
+	}
+	if ok, err := ksm.Exists("db"); ok || err != nil {
+		t.Fatal(ok, err)
+	}
+}
+
+func TestConflict(t *testing.T) {
+	e := checkAzErrResp(respErr(409, `{"error":{"code":"Conflict","message":"Secret a is currently in a deleted but recoverable state"}}`, nil))
+	if e.Code != errors.ErrCodeConflict || e.Status != 409 || !strings.Contains(e.Message, "deleted but recoverable") {
+		t.Fatal(e)
+	}
+}
+
+func TestIsUnwrap(t *testing.T) {
+	var err error = checkAzErrResp(respErr(404, "", nil))
+	if !stdErrors.Is(err, ErrNotFound) || stdErrors.Is(err, ErrForbidden) {
+		t.Fatal(err)
+	}
+	var re *azcore.ResponseError
+	if !stdErrors.As(err, &re) || re.StatusCode != 404 {
+		t.Fatal(re)
+	}
+	err = checkAzErrResp(respErr(403, `{"error":{"innererror":{"code":"ForbiddenByFirewall"}}}`, nil))
+	if !stdErrors.Is(err, ErrForbidden) {
+		t.Fatal(err)
+	}
+	err = checkAzErrResp(context.DeadlineExceeded)
+	if !stdErrors.Is(err, ErrTimeout) || !stdErrors.Is(err, context.DeadlineExceeded) {
+		t.Fatal(err)
+	}
+}
+
+func TestTraceIDOnEveryCode(t *testing.T) {
+	for _, status := range []int{401, 403, 404, 429, 500, 503} {
+		e := checkAzErrResp(respErr(status, `{"error":{"message":"m"}}`, map[string]string{"x-ms-request-id": "req-1"}))
+		if e.TraceId != "req-1" {
+			t.Fatal(status, e)
+		}
+	}
+}
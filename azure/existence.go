@@ -0,0 +1,60 @@
+package azure
+
+import (
+	"sync"
+	"time"
+)
+
+// existenceCache remembers for a short time whether secrets exist, without
+// their values, so that repeated Exists calls don't each reach the vault.
+type existenceCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]existenceEntry
+}
+
+type existenceEntry struct {
+	exists  bool
+	expires time.Time
+}
+
+func newExistenceCache(ttl time.Duration) *existenceCache {
+	return &existenceCache{
+		ttl:     ttl,
+		entries: make(map[string]existenceEntry),
+	}
+}
+
+// get returns the cached presence of the secret, if still valid at now.
+func (ec *existenceCache) get(name string, now time.Time) (exists, ok bool) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	entry, ok := ec.entries[name]
+	if !ok || !now.Before(entry.expires) {
+		return false, false
+	}
+	return entry.exists, true
+}
+
+func (ec *existenceCache) put(name string, exists bool, now time.Time) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	ec.entries[name] = existenceEntry{exists: exists, expires: now.Add(ec.ttl)}
+}
+
+func (ec *existenceCache) invalidate(name string) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	delete(ec.entries, name)
+}
+
+func (ec *existenceCache) clear() {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	clear(ec.entries)
+}
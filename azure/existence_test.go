@@ -0,0 +1,34 @@
+package azure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestExistenceCache(t *testing.T) {
+	calls := 0
+	present := false
+	f := &fakeOps{versions: func(string) ([][]*azsecrets.SecretProperties, error) {
+		calls++
+		if present {
+			return [][]*azsecrets.SecretProperties{props("a/v1")}, nil
+		}
+		return nil, respErr(404, "", nil)
+	}, setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		present = true
+		return azsecrets.SetSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	WithExistenceCache(time.Minute)(ksm.kvClient)
+	ksm.Exists("a")
+	if ok, _ := ksm.Exists("a"); ok || calls != 1 {
+		t.Fatal(calls)
+	}
+	ksm.Set(Secret{Name: "a"})
+	if ok, _ := ksm.Exists("a"); !ok || calls != 2 {
+		t.Fatal(calls)
+	}
+}
@@ -0,0 +1,53 @@
+package azure
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// ListWithoutExpiration returns the secrets that have no expiration date, for
+// policies requiring every secret to expire. Like List, it does not fetch
+// the values.
+func (ksm *KeyVaultSecretsManager) ListWithoutExpiration() ([]Secret, *errors.Error) {
+	var secrets []Secret
+
+	err := ksm.walkSecrets(ksm.kvClient.ctx, func(secret Secret) bool {
+		if secret.Expiration.IsZero() {
+			secrets = append(secrets, secret)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// ListExpiringSoon returns the secrets that expire within the given duration
+// from now, the boundary included, soonest first, e.g. for a job alerting on
+// secrets to rotate. Secrets that have expired already are included; those
+// without an expiration are not. Like List, it does not fetch the values.
+func (ksm *KeyVaultSecretsManager) ListExpiringSoon(ctx context.Context, within time.Duration) ([]Secret, *errors.Error) {
+	deadline := ksm.kvClient.now().Add(within)
+	var secrets []Secret
+
+	err := ksm.walkSecrets(ctx, func(secret Secret) bool {
+		if !secret.Expiration.IsZero() && !secret.Expiration.After(deadline) {
+			secrets = append(secrets, secret)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(secrets, func(i, j int) bool {
+		return secrets[i].Expiration.Before(secrets[j].Expiration)
+	})
+
+	return secrets, nil
+}
@@ -0,0 +1,42 @@
+package azure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestNoExpiry(t *testing.T) {
+	p := props("a", "b", "c")
+	exp := time.Now()
+	p[1].Attributes = &azsecrets.SecretAttributes{Expires: &exp}
+	p[2].Attributes = &azsecrets.SecretAttributes{}
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{p}}
+	l, err := newTestManager(context.Background(), f).ListWithoutExpiration()
+	if err != nil || len(l) != 2 || l[1].Name != "c" {
+		t.Fatal(l)
+	}
+}
+
+func TestExpiringSoon(t *testing.T) {
+	now := time.Unix(1e9, 0)
+	p := func(name string, exp time.Time) *azsecrets.SecretProperties {
+		id := azsecrets.ID("https://vlt.vault.azure.net/secrets/" + name)
+		attrs := &azsecrets.SecretAttributes{}
+		if !exp.IsZero() {
+			attrs.Expires = &exp
+		}
+		return &azsecrets.SecretProperties{ID: &id, Attributes: attrs}
+	}
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{{
+		p("edge", now.Add(30*24*time.Hour)), p("later", now.Add(31*24*time.Hour)), p("never", time.Time{}), p("soon", now.Add(time.Hour)), p("gone", now.Add(-time.Hour)),
+	}}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.kvClient.clock = func() time.Time { return now }
+	s, err := ksm.ListExpiringSoon(context.Background(), 30*24*time.Hour)
+	if err != nil || len(s) != 3 || s[0].Name != "gone" || s[1].Name != "soon" || s[2].Name != "edge" {
+		t.Fatal(s, err)
+	}
+}
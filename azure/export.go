@@ -0,0 +1,110 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// AsMap fetches the values of the secrets of the vault into a map keyed by
+// secret name, e.g. to feed a configuration template. When patterns are
+// given, using the path.Match syntax, only the matching names are fetched.
+//
+// The map holds every value in plaintext: keep it out of logs and dumps and
+// drop it as soon as the values have been consumed. AsMap stops at the first
+// secret it cannot fetch.
+func (ksm *KeyVaultSecretsManager) AsMap(ctx context.Context, patterns ...string) (map[string]string, *errors.Error) {
+	var names []string
+	err := ksm.walkSecrets(ctx, func(secret Secret) bool {
+		if len(patterns) == 0 || matchesAny(patterns, secret.Name) {
+			names = append(names, secret.Name)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		secret, err := ksm.get(ctx, name, "")
+		if err != nil {
+			return nil, err
+		}
+		values[name] = secret.Value
+	}
+
+	return values, nil
+}
+
+// exportConcurrency is the number of values Export fetches in parallel.
+const exportConcurrency = 4
+
+// Export returns every enabled secret of the vault the client is allowed to
+// see, with its value, tags, content type and validity, e.g. to marshal it to
+// JSON for a disaster recovery drill or to seed another vault with Import.
+// Disabled secrets are skipped, since their values cannot be read. A value
+// that cannot be fetched does not stop the others: the failures are returned
+// by secret name, and the secrets missing from the export are those in the
+// map. The error is only set when the secrets cannot be listed.
+//
+// The export holds every value in plaintext: encrypt it at rest and never
+// commit or log it.
+func (ksm *KeyVaultSecretsManager) Export(ctx context.Context) ([]Secret, map[string]*errors.Error, *errors.Error) {
+	var names []string
+	err := ksm.walkSecrets(ctx, func(secret Secret) bool {
+		if secret.IsEnabled() {
+			names = append(names, secret.Name)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fetched, errs := ksm.getMany(ctx, names, exportConcurrency)
+
+	secrets := make([]Secret, 0, len(fetched))
+	for _, name := range names {
+		if secret, ok := fetched[name]; ok {
+			secrets = append(secrets, *secret)
+		}
+	}
+
+	return secrets, errs, nil
+}
+
+// Import writes secrets, e.g. those of an Export, into the vault one at a
+// time, and returns the failures by secret name. Secrets that already exist
+// are skipped unless overwrite is set, in which case they get a new version.
+// When ctx is done, the secrets not written by then are reported with the
+// context error. Expired secrets fail unless WithAllowExpired is set.
+func (ksm *KeyVaultSecretsManager) Import(ctx context.Context, secrets []Secret, overwrite bool) map[string]*errors.Error {
+	errs := make(map[string]*errors.Error)
+
+	for i, secret := range secrets {
+		if ctx.Err() != nil {
+			start := ksm.kvClient.now()
+			for _, secret := range secrets[i:] {
+				errs[secret.Name] = ksm.kvClient.operationError(ctx, opSetSecret, secret.Name, start, ksm.kvClient.azError(ctx.Err()))
+			}
+			break
+		}
+
+		if !overwrite {
+			exists, err := ksm.hasVersion(ctx, secret.Name)
+			if err != nil && err.Code != errors.ErrCodeNotFound {
+				errs[secret.Name] = err
+				continue
+			}
+			if exists {
+				continue
+			}
+		}
+		if err := ksm.SetContext(ctx, secret); err != nil {
+			errs[secret.Name] = err
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,79 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestAsMap(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("v-" + name)}}, nil
+	}, listPages: [][]*azsecrets.SecretProperties{props("a", "db-x"), props("db-y")}}
+	ksm := newTestManager(context.Background(), f)
+	m, err := ksm.AsMap(context.Background())
+	if err != nil || len(m) != 3 || m["db-y"] != "v-db-y" {
+		t.Fatal(m)
+	}
+	f.listPages = [][]*azsecrets.SecretProperties{props("a", "db-x"), props("db-y")}
+	m, _ = ksm.AsMap(context.Background(), "db-*")
+	if len(m) != 2 {
+		t.Fatal(m)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	src := newTestManager(context.Background(), vaultFake())
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	src.Set(Secret{Name: "a", Value: "1", Tags: map[string]string{"k": "v"}, ContentType: "text/plain", Expiration: exp})
+	src.Set(Secret{Name: "b", Value: "2"})
+	out, errs, err := src.Export(context.Background())
+	if err != nil || len(errs) != 0 || len(out) != 2 {
+		t.Fatal(out, errs, err)
+	}
+	data, _ := json.Marshal(out)
+	var in []Secret
+	json.Unmarshal(data, &in)
+	dstFake := vaultFake()
+	dst := newTestManager(context.Background(), dstFake)
+	dst.Set(Secret{Name: "b", Value: "keep"})
+	if errs := dst.Import(context.Background(), in, false); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	a, _ := dst.Get("a")
+	b, _ := dst.Get("b")
+	if a.Value != "1" || a.Tags["k"] != "v" || a.ContentType != "text/plain" || !a.Expiration.Equal(exp) || b.Value != "keep" {
+		t.Fatal(a, b)
+	}
+	dst.Import(context.Background(), in, true)
+	if b, _ := dst.Get("b"); b.Value != "2" {
+		t.Fatal(b)
+	}
+}
+
+func TestExportPartial(t *testing.T) {
+	ps := props("a", "b", "off")
+	ps[2].Attributes = &azsecrets.SecretAttributes{Enabled: to.Ptr(false)}
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		switch name {
+		case "b":
+			return azsecrets.GetSecretResponse{}, respErr(403, "", nil)
+		case "off":
+			t.Error("fetched a disabled secret")
+		}
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("v-" + name)}}, nil
+	}, listPages: [][]*azsecrets.SecretProperties{ps}}
+	ksm := newTestManager(context.Background(), f)
+	out, errs, err := ksm.Export(context.Background())
+	if err != nil || len(out) != 1 || out[0].Name != "a" || out[0].Value != "v-a" {
+		t.Fatal(out, err)
+	}
+	if len(errs) != 1 || errs["b"] == nil || errs["b"].Code != errors.ErrCodeInsufficientAccess {
+		t.Fatal(errs)
+	}
+}
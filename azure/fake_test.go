@@ -0,0 +1,328 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/tracing"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+type fakeOps struct {
+	getSecret    func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error)
+	setSecret    func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error)
+	deleteSecret func(ctx context.Context, name string) (azsecrets.DeleteSecretResponse, error)
+	listPages    [][]*azsecrets.SecretProperties
+	listErr      error
+	versionPages map[string][][]*azsecrets.SecretProperties
+	pagesFetched int
+	updates      []update
+	updateErr    error
+	deletedPages [][]*azsecrets.DeletedSecretProperties
+	deletedErr   error
+	updated      map[string]time.Time
+	getDeleted   func(name string) (azsecrets.GetDeletedSecretResponse, error)
+	purge        func(name string) (azsecrets.PurgeDeletedSecretResponse, error)
+	recover      func(name string) (azsecrets.RecoverDeletedSecretResponse, error)
+	backups      map[string][]byte
+	versionErr   error
+	versions     func(name string) ([][]*azsecrets.SecretProperties, error)
+}
+
+func (f *fakeOps) GetDeletedSecret(_ context.Context, name string, _ *azsecrets.GetDeletedSecretOptions) (azsecrets.GetDeletedSecretResponse, error) {
+	return f.getDeleted(name)
+}
+
+func (f *fakeOps) RecoverDeletedSecret(_ context.Context, name string, _ *azsecrets.RecoverDeletedSecretOptions) (azsecrets.RecoverDeletedSecretResponse, error) {
+	return f.recover(name)
+}
+
+func (f *fakeOps) BackupSecret(_ context.Context, name string, _ *azsecrets.BackupSecretOptions) (azsecrets.BackupSecretResponse, error) {
+	return azsecrets.BackupSecretResponse{BackupSecretResult: azsecrets.BackupSecretResult{Value: []byte("blob:" + name)}}, nil
+}
+
+func (f *fakeOps) RestoreSecret(_ context.Context, p azsecrets.RestoreSecretParameters, _ *azsecrets.RestoreSecretOptions) (azsecrets.RestoreSecretResponse, error) {
+	name := strings.TrimPrefix(string(p.SecretBackup), "blob:")
+	if f.backups == nil {
+		f.backups = map[string][]byte{}
+	}
+	if _, ok := f.backups[name]; ok {
+		return azsecrets.RestoreSecretResponse{}, respErr(409, `{"error":{"message":"already exists"}}`, nil)
+	}
+	f.backups[name] = p.SecretBackup
+	id := azsecrets.ID("https://w.vault.azure.net/secrets/" + name + "/v9")
+	return azsecrets.RestoreSecretResponse{Secret: azsecrets.Secret{ID: &id}}, nil
+}
+
+func (f *fakeOps) PurgeDeletedSecret(_ context.Context, name string, _ *azsecrets.PurgeDeletedSecretOptions) (azsecrets.PurgeDeletedSecretResponse, error) {
+	return f.purge(name)
+}
+
+func (f *fakeOps) GetSecret(ctx context.Context, name string, version string, _ *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error) {
+	return f.getSecret(ctx, name, version)
+}
+
+func (f *fakeOps) SetSecret(ctx context.Context, name string, p azsecrets.SetSecretParameters, _ *azsecrets.SetSecretOptions) (azsecrets.SetSecretResponse, error) {
+	return f.setSecret(ctx, name, p)
+}
+
+func (f *fakeOps) DeleteSecret(ctx context.Context, name string, _ *azsecrets.DeleteSecretOptions) (azsecrets.DeleteSecretResponse, error) {
+	return f.deleteSecret(ctx, name)
+}
+
+func pager[T any](f *fakeOps, pages [][]*azsecrets.SecretProperties, err error, wrap func([]*azsecrets.SecretProperties) T) *runtime.Pager[T] {
+	i := 0
+	return runtime.NewPager(runtime.PagingHandler[T]{
+		More: func(T) bool { return i < len(pages) },
+		Fetcher: func(ctx context.Context, _ *T) (T, error) {
+			var zero T
+			if ctx.Err() != nil {
+				return zero, ctx.Err()
+			}
+			if err != nil {
+				return zero, err
+			}
+			f.pagesFetched++
+			if len(pages) == 0 {
+				return wrap(nil), nil
+			}
+			p := pages[i]
+			i++
+			return wrap(p), nil
+		},
+	})
+}
+
+func (f *fakeOps) NewListSecretPropertiesPager(*azsecrets.ListSecretPropertiesOptions) *runtime.Pager[azsecrets.ListSecretPropertiesResponse] {
+	return pager(f, f.listPages, f.listErr, func(v []*azsecrets.SecretProperties) azsecrets.ListSecretPropertiesResponse {
+		return azsecrets.ListSecretPropertiesResponse{SecretPropertiesListResult: azsecrets.SecretPropertiesListResult{Value: v}}
+	})
+}
+
+func (f *fakeOps) NewListSecretPropertiesVersionsPager(name string, _ *azsecrets.ListSecretPropertiesVersionsOptions) *runtime.Pager[azsecrets.ListSecretPropertiesVersionsResponse] {
+	pages, err := f.versionPages[name], f.versionErr
+	if f.versions != nil {
+		pages, err = f.versions(name)
+	}
+	return pager(f, pages, err, func(v []*azsecrets.SecretProperties) azsecrets.ListSecretPropertiesVersionsResponse {
+		return azsecrets.ListSecretPropertiesVersionsResponse{SecretPropertiesListResult: azsecrets.SecretPropertiesListResult{Value: v}}
+	})
+}
+
+func respErr(status int, body string, hdr map[string]string) error {
+	h := http.Header{}
+	for k, v := range hdr {
+		h.Set(k, v)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://x.vault.azure.net/secrets/a", nil)
+	resp := &http.Response{StatusCode: status, Header: h, Body: io.NopCloser(bytes.NewBufferString(body)), Request: req}
+	return runtime.NewResponseError(resp)
+}
+
+func newTestManager(ctx context.Context, f *fakeOps) *KeyVaultSecretsManager {
+	return &KeyVaultSecretsManager{kvClient: &KeyVaultClient{ctx: ctx, vaultName: "v"}, secretsClient: f}
+}
+
+func props(ids ...string) []*azsecrets.SecretProperties {
+	var out []*azsecrets.SecretProperties
+	for _, id := range ids {
+		i := azsecrets.ID("https://vlt.vault.azure.net/secrets/" + id)
+		out = append(out, &azsecrets.SecretProperties{ID: &i})
+	}
+	return out
+}
+
+type recObs struct{ msgs []string }
+
+func (r *recObs) Warning(op, name, msg string) { r.msgs = append(r.msgs, op+":"+name+":"+msg) }
+
+func strp(s string) *string { return &s }
+
+// countingStore is a MemoryStore counting the reads and writes that reach it.
+type countingStore struct {
+	*MemoryStore
+	mu   sync.Mutex
+	gets map[string]int
+	sets int
+}
+
+func newCountingStore(secrets ...Secret) *countingStore {
+	return &countingStore{MemoryStore: NewMemoryStore(secrets...), gets: map[string]int{}}
+}
+
+func (c *countingStore) Get(name string) (*Secret, *errors.Error) {
+	c.mu.Lock()
+	c.gets[name]++
+	c.mu.Unlock()
+	return c.MemoryStore.Get(name)
+}
+
+func (c *countingStore) Set(secret Secret) *errors.Error {
+	c.mu.Lock()
+	c.sets++
+	c.mu.Unlock()
+	return c.MemoryStore.Set(secret)
+}
+
+type fakeCred struct {
+	err   error
+	calls int
+}
+
+func (f *fakeCred) GetToken(ctx context.Context, o policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	f.calls++
+	return azcore.AccessToken{Token: "t", ExpiresOn: time.Now().Add(time.Hour)}, f.err
+}
+
+func (f *fakeOps) UpdateSecretProperties(ctx context.Context, name string, version string, p azsecrets.UpdateSecretPropertiesParameters, _ *azsecrets.UpdateSecretPropertiesOptions) (azsecrets.UpdateSecretPropertiesResponse, error) {
+	f.updates = append(f.updates, update{name, version, p, ctx})
+	if f.updateErr != nil {
+		return azsecrets.UpdateSecretPropertiesResponse{}, f.updateErr
+	}
+	u := time.Now()
+	if f.updated != nil {
+		f.updated[name] = u
+	}
+	return azsecrets.UpdateSecretPropertiesResponse{Secret: azsecrets.Secret{Attributes: &azsecrets.SecretAttributes{Updated: &u}}}, nil
+}
+
+type update struct {
+	name, version string
+	p             azsecrets.UpdateSecretPropertiesParameters
+	ctx           context.Context
+}
+
+func (f *fakeOps) NewListDeletedSecretPropertiesPager(*azsecrets.ListDeletedSecretPropertiesOptions) *runtime.Pager[azsecrets.ListDeletedSecretPropertiesResponse] {
+	i := 0
+	return runtime.NewPager(runtime.PagingHandler[azsecrets.ListDeletedSecretPropertiesResponse]{
+		More: func(azsecrets.ListDeletedSecretPropertiesResponse) bool { return i < len(f.deletedPages) },
+		Fetcher: func(ctx context.Context, _ *azsecrets.ListDeletedSecretPropertiesResponse) (azsecrets.ListDeletedSecretPropertiesResponse, error) {
+			if f.deletedErr != nil {
+				return azsecrets.ListDeletedSecretPropertiesResponse{}, f.deletedErr
+			}
+			p := f.deletedPages[i]
+			i++
+			return azsecrets.ListDeletedSecretPropertiesResponse{DeletedSecretPropertiesListResult: azsecrets.DeletedSecretPropertiesListResult{Value: p}}, nil
+		},
+	})
+}
+
+func dprops(ids ...string) []*azsecrets.DeletedSecretProperties {
+	var out []*azsecrets.DeletedSecretProperties
+	for _, id := range ids {
+		i := azsecrets.ID("https://vlt.vault.azure.net/secrets/" + id)
+		out = append(out, &azsecrets.DeletedSecretProperties{ID: &i})
+	}
+	return out
+}
+
+type fixedResolver []net.IPAddr
+
+func (r fixedResolver) LookupIPAddr(context.Context, string) ([]net.IPAddr, error) {
+	if r == nil {
+		return nil, &net.DNSError{Err: "no such host", IsNotFound: true}
+	}
+	return r, nil
+}
+
+type ctxKey struct{}
+
+func conformance(t *testing.T, s SecretStore) {
+	if _, err := s.Get("nope"); err == nil || err.Code != errors.ErrCodeNotFound {
+		t.Fatal(err)
+	}
+	if err := s.Set(Secret{Name: "a", Value: "1", Tags: map[string]string{"k": "v"}}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Get("a")
+	if err != nil || got.Value != "1" || got.Tags["k"] != "v" {
+		t.Fatal(got, err)
+	}
+	if ok, _ := s.Exists("a"); !ok {
+		t.Fatal("exists")
+	}
+	l, _ := s.List()
+	if len(l) != 1 || l[0].Value != "" {
+		t.Fatal(l)
+	}
+	if err := s.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Exists("a"); ok {
+		t.Fatal("deleted")
+	}
+}
+
+type fakeProvider struct {
+	defaults int
+	cred     azcore.TokenCredential
+}
+
+func (p *fakeProvider) NewDefaultAzureCredential(*azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
+	p.defaults++
+	return p.cred, nil
+}
+
+type fakeMIProvider struct {
+	fakeProvider
+	got *azidentity.ManagedIdentityCredentialOptions
+}
+
+func (p *fakeMIProvider) NewManagedIdentityCredential(o *azidentity.ManagedIdentityCredentialOptions) (azcore.TokenCredential, error) {
+	p.got = o
+	return p.cred, nil
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+type recordedSpan struct {
+	name   string
+	attrs  map[string]any
+	status tracing.SpanStatus
+	ended  bool
+}
+
+func vaultFake() *fakeOps {
+	store := map[string]azsecrets.SetSecretParameters{}
+	f := &fakeOps{}
+	f.setSecret = func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		store[name] = p
+		var ps []*azsecrets.SecretProperties
+		for n := range store {
+			ps = append(ps, props(n)...)
+		}
+		sort.Slice(ps, func(i, j int) bool { return ps[i].ID.Name() < ps[j].ID.Name() })
+		f.listPages = [][]*azsecrets.SecretProperties{ps}
+		return azsecrets.SetSecretResponse{}, nil
+	}
+	f.getSecret = func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		p, ok := store[name]
+		if !ok {
+			return azsecrets.GetSecretResponse{}, respErr(404, "", nil)
+		}
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: p.Value, Tags: p.Tags, ContentType: p.ContentType, Attributes: p.SecretAttributes}}, nil
+	}
+	f.versions = func(name string) ([][]*azsecrets.SecretProperties, error) {
+		if _, ok := store[name]; !ok {
+			return nil, nil
+		}
+		return [][]*azsecrets.SecretProperties{props(name + "/v1")}, nil
+	}
+	return f
+}
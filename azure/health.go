@@ -0,0 +1,132 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// healthCacheTTL is how long HealthHandler reuses the result of a check.
+const healthCacheTTL = 10 * time.Second
+
+// healthCheckTimeout bounds a check run by HealthHandler, which does not end
+// with the probe that started it.
+const healthCheckTimeout = 5 * time.Second
+
+// HealthStatus is the JSON body served by HealthHandler.
+type HealthStatus struct {
+	Healthy    bool        `json:"healthy"`
+	Reachable  bool        `json:"reachable"`
+	Authorized bool        `json:"authorized"`
+	LatencyMs  int64       `json:"latencyMs"`
+	Error      errors.Code `json:"error,omitempty"`
+	CheckedAt  time.Time   `json:"checkedAt"`
+}
+
+// ping performs the lightest authenticated call available: fetching the
+// first page of secret properties.
+func (ksm *KeyVaultSecretsManager) ping(ctx context.Context) *errors.Error {
+	pager := ksm.secretsClient.NewListSecretPropertiesPager(nil)
+	if !pager.More() {
+		return nil
+	}
+	if _, err := pager.NextPage(ctx); err != nil {
+		return ksm.kvClient.azError(err)
+	}
+	return nil
+}
+
+// HealthCheck confirms that the vault is reachable and the credential
+// authorized with a single page of secret properties, e.g. for a readiness
+// probe. It returns an Unauthorized or InsufficientAccess error when
+// authentication or RBAC is misconfigured and an InternalServerError when the
+// vault cannot be reached.
+func (ksm *KeyVaultSecretsManager) HealthCheck(ctx context.Context) *errors.Error {
+	start := ksm.kvClient.now()
+	return ksm.kvClient.operationError(ctx, opListSecrets, "", start, ksm.ping(ctx))
+}
+
+// HealthHandler returns an http.Handler reporting whether the vault is
+// reachable and the credential authorized, with 200 when it is healthy and
+// 503 otherwise. Results are reused for a few seconds so that frequent probes
+// do not load the vault.
+//
+// Concurrent probes share a single check, which runs detached from their
+// requests with its own timeout: a probe that gives up neither cancels the
+// check for the others nor gets its cancellation cached as the result.
+func (ksm *KeyVaultSecretsManager) HealthHandler() http.Handler {
+	var mu sync.Mutex
+	var last *HealthStatus
+	var running chan struct{}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		if last == nil || ksm.kvClient.now().Sub(last.CheckedAt) >= healthCacheTTL {
+			if running == nil {
+				done := make(chan struct{})
+				running = done
+				go func(ctx context.Context) {
+					ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+					defer cancel()
+					status := ksm.checkHealth(ctx)
+
+					mu.Lock()
+					last = &status
+					running = nil
+					mu.Unlock()
+					close(done)
+				}(context.WithoutCancel(r.Context()))
+			}
+			done := running
+			mu.Unlock()
+
+			select {
+			case <-done:
+			case <-r.Context().Done():
+				return
+			}
+			mu.Lock()
+		}
+		status := *last
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if status.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(status)
+	})
+}
+
+// checkHealth pings the vault and classifies the outcome.
+func (ksm *KeyVaultSecretsManager) checkHealth(ctx context.Context) HealthStatus {
+	start := ksm.kvClient.now()
+	err := ksm.ping(ctx)
+	status := HealthStatus{
+		LatencyMs: ksm.kvClient.now().Sub(start).Milliseconds(),
+		CheckedAt: start,
+	}
+
+	if err == nil {
+		status.Healthy = true
+		status.Reachable = true
+		status.Authorized = true
+		return status
+	}
+
+	status.Error = err.Code
+	switch err.Code {
+	case errors.ErrCodeUnauthorized, errors.ErrCodeInsufficientAccess, errors.ErrCodeForbiddenByFirewall:
+		// The vault answered, so only the credential, its permissions or
+		// the network rules of the vault are at fault.
+		status.Reachable = true
+	}
+
+	return status
+}
@@ -0,0 +1,91 @@
+package azure
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestHealth(t *testing.T) {
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{props("a")}}
+	ksm := newTestManager(context.Background(), f)
+	now := time.Unix(100, 0)
+	ksm.kvClient.clock = func() time.Time { return now }
+	h := ksm.HealthHandler()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	if rec.Code != 200 {
+		t.Fatal(rec.Code, rec.Body.String())
+	}
+	f.listErr = respErr(403, "", nil)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	if rec.Code != 200 {
+		t.Fatal("cached")
+	}
+	now = now.Add(time.Minute)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	t.Log(rec.Body.String())
+	if rec.Code != 503 {
+		t.Fatal(rec.Code)
+	}
+}
+
+func TestCheckHealthReachable(t *testing.T) {
+	f := &fakeOps{}
+	ksm := newTestManager(context.Background(), f)
+	for _, c := range []struct {
+		err       error
+		code      errors.Code
+		reachable bool
+	}{
+		{respErr(401, "", nil), errors.ErrCodeUnauthorized, true},
+		{respErr(403, `{"error":{"code":"Forbidden","innererror":{"code":"ForbiddenByRbac"}}}`, nil), errors.ErrCodeInsufficientAccess, true},
+		{respErr(403, `{"error":{"code":"Forbidden","innererror":{"code":"ForbiddenByFirewall"}}}`, nil), errors.ErrCodeForbiddenByFirewall, true},
+		{stdErrors.New("dial tcp: connection refused"), errors.ErrCodeInternalServerError, false},
+	} {
+		f.listPages = [][]*azsecrets.SecretProperties{props("a")}
+		f.listErr = c.err
+		status := ksm.checkHealth(context.Background())
+		if status.Healthy || status.Authorized || status.Error != c.code || status.Reachable != c.reachable {
+			t.Fatal(c.code, status)
+		}
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{props("a"), props("b")}}
+	ksm := newTestManager(context.Background(), f)
+	if err := ksm.HealthCheck(context.Background()); err != nil || f.pagesFetched != 1 {
+		t.Fatal(err, f.pagesFetched)
+	}
+	f.listErr = respErr(403, `{"error":{"code":"Forbidden","message":"denied","innererror":{"code":"ForbiddenByRbac"}}}`, nil)
+	if err := ksm.HealthCheck(context.Background()); err == nil || err.Code != errors.ErrCodeInsufficientAccess {
+		t.Fatal(err)
+	}
+	f.listErr = stdErrors.New("dial tcp: connection refused")
+	if err := ksm.HealthCheck(context.Background()); err == nil || err.Code != errors.ErrCodeInternalServerError {
+		t.Fatal(err)
+	}
+}
+
+func TestHealthCallerCancel(t *testing.T) {
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{props("a")}}
+	ksm := newTestManager(context.Background(), f)
+	h := ksm.HealthHandler()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/healthz", nil).WithContext(ctx))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	if rec.Code != 200 {
+		t.Fatal("caller cancellation cached:", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,105 @@
+package azure
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/url"
+	"runtime/debug"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// Version is the version of this module. Release builds override it with
+//
+//	-ldflags "-X github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/azure.Version=v1.2.3"
+var Version = "dev"
+
+// DefaultAPIVersion is the KeyVault service API version the azsecrets client
+// uses unless WithAPIVersion sets another.
+const DefaultAPIVersion = "2025-07-01"
+
+const azsecretsModulePath = "github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+// Info reports the versions a KeyVaultClient talks to the service with, for
+// support tickets and diagnostics.
+type Info struct {
+	VaultURL   string `json:"vaultUrl"`
+	APIVersion string `json:"apiVersion"`
+	SDKVersion string `json:"sdkVersion"`
+	Version    string `json:"version"`
+}
+
+// ClientInfo returns the service API version, the azsecrets SDK version and
+// the version of this module used by the client.
+func (kvc *KeyVaultClient) ClientInfo() Info {
+	apiVersion := kvc.apiVersion
+	if apiVersion == "" {
+		apiVersion = DefaultAPIVersion
+	}
+
+	return Info{
+		VaultURL:   kvc.vaultURL,
+		APIVersion: apiVersion,
+		SDKVersion: moduleVersion(azsecretsModulePath),
+		Version:    Version,
+	}
+}
+
+// Resolver looks up the IP addresses of a host. *net.Resolver implements it.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// ResolvedEndpoint returns the vault URL and the IP addresses its host
+// resolves to from here, to tell whether traffic goes to the public endpoint
+// or to a private one. A host that does not resolve is a NotFound error.
+func (kvc *KeyVaultClient) ResolvedEndpoint(ctx context.Context) (string, []net.IP, *errors.Error) {
+	u, err := url.Parse(kvc.vaultURL)
+	if err != nil {
+		return kvc.vaultURL, nil, errors.ValidationError(fmt.Sprintf("invalid vault URL %q: %v", kvc.vaultURL, err))
+	}
+
+	resolver := kvc.resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	addrs, err := resolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		var dnsErr *net.DNSError
+		if stderrors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return kvc.vaultURL, nil, errors.NotFoundError(fmt.Sprintf("vault host %s does not resolve: %v", u.Hostname(), err))
+		}
+		return kvc.vaultURL, nil, kvc.azError(err)
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP)
+	}
+
+	return kvc.vaultURL, ips, nil
+}
+
+// moduleVersion returns the version of the given dependency as recorded in
+// the build info of the running binary, or "unknown".
+func moduleVersion(path string) string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path != path {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+
+	return "unknown"
+}
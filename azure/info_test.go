@@ -0,0 +1,29 @@
+package azure
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestInfo(t *testing.T) {
+	i := (&KeyVaultClient{vaultURL: "u"}).ClientInfo()
+	t.Log(i)
+	if i.SDKVersion == "unknown" || i.APIVersion == "" || i.Version == "" {
+		t.Fatal(i)
+	}
+}
+
+func TestResolvedEndpoint(t *testing.T) {
+	kvc := &KeyVaultClient{vaultURL: "https://vlt.vault.azure.net", resolver: fixedResolver{{IP: net.ParseIP("10.0.0.4")}}}
+	u, ips, err := kvc.ResolvedEndpoint(context.Background())
+	if err != nil || u != "https://vlt.vault.azure.net" || len(ips) != 1 || ips[0].String() != "10.0.0.4" {
+		t.Fatal(u, ips, err)
+	}
+	kvc.resolver = fixedResolver(nil)
+	if _, _, err := kvc.ResolvedEndpoint(context.Background()); err == nil || err.Code != errors.ErrCodeNotFound {
+		t.Fatal(err)
+	}
+}
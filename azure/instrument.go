@@ -0,0 +1,76 @@
+package azure
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/tracing"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// tracerName is the instrumentation scope of the spans of this package.
+const tracerName = "github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/azure"
+
+// Names of the manager operations, as spans and in logs.
+const (
+	spanList   = "keyvault.List"
+	spanGet    = "keyvault.Get"
+	spanSet    = "keyvault.Set"
+	spanDelete = "keyvault.Delete"
+	spanRotate = "keyvault.Rotate"
+)
+
+// Attributes of the spans and logs. Values are never recorded.
+const (
+	attrVaultName  = "keyvault.vault.name"
+	attrSecretName = "keyvault.secret.name"
+)
+
+// startOperation starts the span of an operation on the secret with the
+// given name, or on the whole vault when it is empty, and returns the context
+// to run the operation under and the function ending it with its error, which
+// also logs the outcome at debug level. The span is named with the prefix
+// set by WithOperationNamePrefix. Without a tracing provider the span
+// is a no-op, and without a logger nothing is logged.
+func (kvc *KeyVaultClient) startOperation(ctx context.Context, operation, name string) (context.Context, func(*errors.Error)) {
+	attrs := []tracing.Attribute{{Key: attrVaultName, Value: kvc.vaultName}}
+	if name != "" {
+		attrs = append(attrs, tracing.Attribute{Key: attrSecretName, Value: name})
+	}
+
+	start := kvc.now()
+	ctx, span := kvc.tracer.Start(ctx, kvc.operationName(operation), &tracing.SpanOptions{Kind: tracing.SpanKindInternal, Attributes: attrs})
+	return ctx, func(err *errors.Error) {
+		if err != nil {
+			span.SetStatus(tracing.SpanStatusError, err.Error())
+		}
+		span.End()
+		kvc.logOperation(ctx, operation, name, start, err)
+	}
+}
+
+// logOperation logs the outcome of an operation at debug level, under its
+// prefixed name.
+func (kvc *KeyVaultClient) logOperation(ctx context.Context, operation, name string, start time.Time, err *errors.Error) {
+	if kvc.logger == nil || !kvc.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", kvc.operationName(operation)),
+		slog.String(attrVaultName, kvc.vaultName),
+	}
+	if name != "" {
+		attrs = append(attrs, slog.String(attrSecretName, name))
+	}
+	attrs = append(attrs, slog.Duration("duration", kvc.now().Sub(start)))
+	if err != nil {
+		attrs = append(attrs, slog.String("outcome", string(err.Code)), slog.String("error", err.Message))
+	} else {
+		attrs = append(attrs, slog.String("outcome", "ok"))
+	}
+
+	kvc.logger.LogAttrs(ctx, slog.LevelDebug, "keyvault operation", attrs...)
+}
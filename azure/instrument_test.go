@@ -0,0 +1,94 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/tracing"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestTracing(t *testing.T) {
+	var spans []*recordedSpan
+	provider := tracing.NewProvider(func(name, version string) tracing.Tracer {
+		return tracing.NewTracer(func(ctx context.Context, spanName string, o *tracing.SpanOptions) (context.Context, tracing.Span) {
+			r := &recordedSpan{name: spanName, attrs: map[string]any{}}
+			for _, a := range o.Attributes {
+				r.attrs[a.Key] = a.Value
+			}
+			spans = append(spans, r)
+			return ctx, tracing.NewSpan(tracing.SpanImpl{End: func() { r.ended = true }, SetStatus: func(s tracing.SpanStatus, _ string) { r.status = s }})
+		}, nil)
+	}, nil)
+	c, _ := newClient(&fakeProvider{cred: &fakeCred{}}, WithTracingProvider(provider))
+	kvc, _ := NewKeyVaultClient(context.Background(), c, "vlt")
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{}, respErr(404, "", nil)
+	}, setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		return azsecrets.SetSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.kvClient.vaultName = "vlt"
+	ksm.kvClient.tracer = kvc.tracer
+	ksm.Get("db")
+	ksm.Set(Secret{Name: "db", Value: "hunter2"})
+	if len(spans) != 2 || spans[0].name != "keyvault.Get" || spans[0].attrs["keyvault.secret.name"] != "db" || spans[0].attrs["keyvault.vault.name"] != "vlt" || spans[0].status != tracing.SpanStatusError || !spans[0].ended {
+		t.Fatal(spans[0])
+	}
+	if spans[1].name != "keyvault.Set" || spans[1].status != tracing.SpanStatusUnset {
+		t.Fatal(spans[1])
+	}
+	for _, v := range spans[1].attrs {
+		if v == "hunter2" {
+			t.Fatal()
+		}
+	}
+}
+
+func TestTracingPrefix(t *testing.T) {
+	var names []string
+	provider := tracing.NewProvider(func(name, version string) tracing.Tracer {
+		return tracing.NewTracer(func(ctx context.Context, spanName string, o *tracing.SpanOptions) (context.Context, tracing.Span) {
+			names = append(names, spanName)
+			return ctx, tracing.Span{}
+		}, nil)
+	}, nil)
+	c, _ := newClient(&fakeProvider{cred: &fakeCred{}}, WithTracingProvider(provider))
+	kvc, _ := NewKeyVaultClient(context.Background(), c, "vlt")
+	ksm := newTestManager(context.Background(), &fakeOps{})
+	ksm.kvClient.tracer = kvc.tracer
+	ksm.kvClient.operationPrefix = "payments."
+	ksm.List()
+	if len(names) != 1 || names[0] != "payments.keyvault.List" {
+		t.Fatal(names)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	c, _ := newClient(&fakeProvider{cred: &fakeCred{}}, WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	kvc, _ := NewKeyVaultClient(context.Background(), c, "vlt")
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: to.Ptr("hunter2")}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.kvClient.vaultName = "vlt"
+	ksm.kvClient.logger = kvc.logger
+	if _, err := ksm.Get("db"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Count(out, "\n") != 1 || strings.Contains(out, "hunter2") || !strings.Contains(out, `"keyvault.secret.name":"db"`) || !strings.Contains(out, `"outcome":"ok"`) {
+		t.Fatal(out)
+	}
+	buf.Reset()
+	ksm.kvClient.operationPrefix = "payments."
+	ksm.Get("db")
+	if out := buf.String(); !strings.Contains(out, `"operation":"payments.keyvault.Get"`) {
+		t.Fatal(out)
+	}
+}
@@ -0,0 +1,49 @@
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// jsonContentType is the content type of the secrets written by SetJSON.
+const jsonContentType = "application/json"
+
+// SetJSON stores v, marshaled to JSON, as the secret with the given name, with
+// the application/json content type. A value that cannot be marshaled is a
+// Validation error.
+func (ksm *KeyVaultSecretsManager) SetJSON(name string, v any) *errors.Error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.ValidationError(fmt.Sprintf("secret %q cannot be marshaled to JSON: %v", name, err))
+	}
+
+	return ksm.Set(Secret{Name: name, Value: string(data), ContentType: jsonContentType})
+}
+
+// GetJSON reads the secret with the given name and unmarshals its value into
+// the value v points to. A secret with a content type other than
+// application/json, or whose value is not valid JSON for v, is a Validation
+// error. Secrets without a content type are accepted, since not every writer
+// sets one.
+func (ksm *KeyVaultSecretsManager) GetJSON(name string, v any) *errors.Error {
+	secret, err := ksm.Get(name)
+	if err != nil {
+		return err
+	}
+
+	if secret.ContentType != "" {
+		mediaType, _, parseErr := mime.ParseMediaType(secret.ContentType)
+		if parseErr != nil || mediaType != jsonContentType {
+			return errors.ValidationError(fmt.Sprintf("secret %q has content type %q, not %s", name, secret.ContentType, jsonContentType))
+		}
+	}
+
+	if unmarshalErr := json.Unmarshal([]byte(secret.Value), v); unmarshalErr != nil {
+		return errors.ValidationError(fmt.Sprintf("secret %q does not hold valid JSON for %T: %v", name, v, unmarshalErr))
+	}
+
+	return nil
+}
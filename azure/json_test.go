@@ -0,0 +1,50 @@
+package azure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestJSON(t *testing.T) {
+	type inner struct {
+		Hosts []string `json:"hosts"`
+		Port  int      `json:"port"`
+	}
+	type config struct {
+		User string `json:"user"`
+		DB   inner  `json:"db"`
+	}
+	store := map[string]azsecrets.SetSecretParameters{}
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		store[name] = p
+		return azsecrets.SetSecretResponse{}, nil
+	}, getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		p := store[name]
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: p.Value, ContentType: p.ContentType}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	in := config{User: "app", DB: inner{Hosts: []string{"a", "b"}, Port: 5432}}
+	if err := ksm.SetJSON("cfg", in); err != nil || *store["cfg"].ContentType != "application/json" {
+		t.Fatal(err)
+	}
+	var out config
+	if err := ksm.GetJSON("cfg", &out); err != nil || out.User != "app" || out.DB.Port != 5432 || len(out.DB.Hosts) != 2 {
+		t.Fatal(err, out)
+	}
+	store["txt"] = azsecrets.SetSecretParameters{Value: to.Ptr(`{}`), ContentType: to.Ptr("text/plain")}
+	if err := ksm.GetJSON("txt", &out); err == nil || err.Code != errors.ErrCodeValidation {
+		t.Fatal(err)
+	}
+	store["bad"] = azsecrets.SetSecretParameters{Value: to.Ptr(`{`)}
+	if err := ksm.GetJSON("bad", &out); err == nil || err.Code != errors.ErrCodeValidation {
+		t.Fatal(err)
+	}
+	if err := ksm.SetJSON("ch", make(chan int)); err == nil || err.Code != errors.ErrCodeValidation {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,500 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// Secret is a KeyVault secret. A nil Enabled counts as enabled, so that Set
+// never disables a secret unless asked to. Version, CreatedOn and UpdatedOn
+// are assigned by KeyVault and ignored by Set.
+type Secret struct {
+	Name               string            `json:"name"`
+	Version            string            `json:"version,omitempty"`
+	Value              string            `json:"value"`
+	ContentType        string            `json:"contentType,omitempty"`
+	Expiration         time.Time         `json:"expiration"`
+	NotBefore          time.Time         `json:"notBefore"`
+	CreatedOn          time.Time         `json:"createdOn"`
+	UpdatedOn          time.Time         `json:"updatedOn"`
+	Enabled            *bool             `json:"enabled,omitempty"`
+	Tags               map[string]string `json:"tags,omitempty"`
+	Deleted            bool              `json:"deleted,omitempty"`
+	DeletedDate        time.Time         `json:"deletedDate"`
+	ScheduledPurgeDate time.Time         `json:"scheduledPurgeDate"`
+
+	redaction RedactionStyle
+}
+
+// clone returns a copy of the secret that shares no memory with it, so that
+// neither can be changed through the other.
+func (s Secret) clone() Secret {
+	s.Tags = maps.Clone(s.Tags)
+	if s.Enabled != nil {
+		enabled := *s.Enabled
+		s.Enabled = &enabled
+	}
+	return s
+}
+
+// IsEnabled reports whether the secret is enabled, which it is unless Enabled
+// is set to false.
+func (s Secret) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// ISecretReader is the read-only subset of IKeyVaultSecret, for code that
+// must not modify the vault.
+type ISecretReader interface {
+	List() ([]Secret, *errors.Error)
+	Get(name string) (*Secret, *errors.Error)
+	Exists(name string) (bool, *errors.Error)
+}
+
+// ISecretWriter is the write-only subset of IKeyVaultSecret.
+type ISecretWriter interface {
+	Set(secret Secret) *errors.Error
+	Delete(name string) *errors.Error
+}
+
+// SecretStore is the set of secret operations that does not depend on the
+// backend, so that consumers can be pointed at KeyVault, a MemoryStore or
+// any other implementation without changing their call sites.
+type SecretStore interface {
+	ISecretReader
+	ISecretWriter
+}
+
+// IKeyVaultSecret is the set of secret operations supported on a KeyVault.
+// KeyVaultSecretsManager is the reference SecretStore implementation.
+type IKeyVaultSecret interface {
+	SecretStore
+}
+
+var (
+	_ IKeyVaultSecret = (*KeyVaultSecretsManager)(nil)
+	_ SecretStore     = (*KeyVaultSecretsManager)(nil)
+	_ ISecretReader   = (*KeyVaultSecretsManager)(nil)
+	_ ISecretWriter   = (*KeyVaultSecretsManager)(nil)
+)
+
+// AzKeyVaultSecretsClientOperations is the subset of *azsecrets.Client used by
+// KeyVaultSecretsManager, so that it can be replaced in tests.
+type AzKeyVaultSecretsClientOperations interface {
+	GetSecret(ctx context.Context, name string, version string, options *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error)
+	SetSecret(ctx context.Context, name string, parameters azsecrets.SetSecretParameters, options *azsecrets.SetSecretOptions) (azsecrets.SetSecretResponse, error)
+	DeleteSecret(ctx context.Context, name string, options *azsecrets.DeleteSecretOptions) (azsecrets.DeleteSecretResponse, error)
+	GetDeletedSecret(ctx context.Context, name string, options *azsecrets.GetDeletedSecretOptions) (azsecrets.GetDeletedSecretResponse, error)
+	PurgeDeletedSecret(ctx context.Context, name string, options *azsecrets.PurgeDeletedSecretOptions) (azsecrets.PurgeDeletedSecretResponse, error)
+	RecoverDeletedSecret(ctx context.Context, name string, options *azsecrets.RecoverDeletedSecretOptions) (azsecrets.RecoverDeletedSecretResponse, error)
+	BackupSecret(ctx context.Context, name string, options *azsecrets.BackupSecretOptions) (azsecrets.BackupSecretResponse, error)
+	RestoreSecret(ctx context.Context, parameters azsecrets.RestoreSecretParameters, options *azsecrets.RestoreSecretOptions) (azsecrets.RestoreSecretResponse, error)
+	UpdateSecretProperties(ctx context.Context, name string, version string, parameters azsecrets.UpdateSecretPropertiesParameters, options *azsecrets.UpdateSecretPropertiesOptions) (azsecrets.UpdateSecretPropertiesResponse, error)
+	NewListSecretPropertiesPager(options *azsecrets.ListSecretPropertiesOptions) *runtime.Pager[azsecrets.ListSecretPropertiesResponse]
+	NewListSecretPropertiesVersionsPager(name string, options *azsecrets.ListSecretPropertiesVersionsOptions) *runtime.Pager[azsecrets.ListSecretPropertiesVersionsResponse]
+	NewListDeletedSecretPropertiesPager(options *azsecrets.ListDeletedSecretPropertiesOptions) *runtime.Pager[azsecrets.ListDeletedSecretPropertiesResponse]
+}
+
+// KeyVaultSecretsManager implements IKeyVaultSecret on top of a KeyVaultClient.
+type KeyVaultSecretsManager struct {
+	kvClient      *KeyVaultClient
+	secretsClient AzKeyVaultSecretsClientOperations
+}
+
+// NewKeyVaultSecretsManager creates a KeyVaultSecretsManager for the vault of
+// kvClient.
+func NewKeyVaultSecretsManager(kvClient *KeyVaultClient) *KeyVaultSecretsManager {
+	return &KeyVaultSecretsManager{
+		kvClient:      kvClient,
+		secretsClient: kvClient.secretsClient,
+	}
+}
+
+// WithContext returns a view of the manager whose operations run under ctx
+// instead of the context the client was created with, e.g. the context of an
+// incoming request. The view shares the secrets client and the caches of the
+// manager.
+func (ksm *KeyVaultSecretsManager) WithContext(ctx context.Context) IKeyVaultSecret {
+	kvClient := *ksm.kvClient
+	kvClient.ctx = ctx
+
+	return &KeyVaultSecretsManager{
+		kvClient:      &kvClient,
+		secretsClient: ksm.secretsClient,
+	}
+}
+
+// List returns the properties of every secret in the vault that the client is
+// allowed to see, followed by the soft-deleted ones, flagged as Deleted, when
+// WithIncludeDeleted is set. Values are not fetched, so Secret.Value is
+// always empty. Entries returned without an ID are skipped and reported to
+// the observer.
+func (ksm *KeyVaultSecretsManager) List() ([]Secret, *errors.Error) {
+	return ksm.ListContext(ksm.kvClient.ctx)
+}
+
+// ListContext is List under ctx instead of the context of the client, so that
+// a single call can have its own deadline or be cancelled on its own.
+func (ksm *KeyVaultSecretsManager) ListContext(ctx context.Context) ([]Secret, *errors.Error) {
+	ctx, end := ksm.kvClient.startOperation(ctx, spanList, "")
+	secrets, err := ksm.list(ctx)
+	end(err)
+	return secrets, err
+}
+
+// list is ListContext without its instrumentation.
+func (ksm *KeyVaultSecretsManager) list(ctx context.Context) ([]Secret, *errors.Error) {
+	var secrets []Secret
+	collect := func(secret Secret) bool {
+		secrets = append(secrets, secret)
+		return true
+	}
+
+	if err := ksm.walkSecrets(ctx, collect); err != nil {
+		return nil, err
+	}
+	if ksm.kvClient.includeDeleted {
+		if err := ksm.walkDeletedSecrets(ctx, collect); err != nil {
+			return nil, err
+		}
+	}
+
+	return secrets, nil
+}
+
+// Get returns the latest version of the secret with the given name.
+func (ksm *KeyVaultSecretsManager) Get(name string) (*Secret, *errors.Error) {
+	return ksm.GetContext(ksm.kvClient.ctx, name)
+}
+
+// GetContext is Get under ctx instead of the context of the client.
+func (ksm *KeyVaultSecretsManager) GetContext(ctx context.Context, name string) (*Secret, *errors.Error) {
+	ctx, end := ksm.kvClient.startOperation(ctx, spanGet, name)
+	secret, err := ksm.get(ctx, name, "")
+	end(err)
+	return secret, err
+}
+
+// GetValue returns only the value of the latest version of the secret with
+// the given name, for callers that need nothing else. A secret without a
+// value is a NotFound error, while an empty value is returned as is; reading
+// a disabled one is a SecretDisabled error.
+func (ksm *KeyVaultSecretsManager) GetValue(name string) (string, *errors.Error) {
+	ctx, end := ksm.kvClient.startOperation(ksm.kvClient.ctx, spanGet, name)
+	resp, err := ksm.readSecret(ctx, name, "")
+	if err == nil && resp.Value == nil {
+		err = errors.NotFoundError(fmt.Sprintf("secret %q has no value", name))
+	}
+	if err == nil {
+		err = ksm.kvClient.checkUTF8(name, *resp.Value)
+	}
+	end(err)
+	if err != nil {
+		return "", err
+	}
+	return *resp.Value, nil
+}
+
+// GetVersion returns the given version of the secret with the given name,
+// e.g. to pin a known good version during a rollback. An empty version is the
+// latest one, as with Get. A version that does not exist is a NotFound error,
+// like a missing secret.
+func (ksm *KeyVaultSecretsManager) GetVersion(name, version string) (*Secret, *errors.Error) {
+	return ksm.get(ksm.kvClient.ctx, name, version)
+}
+
+// get is GetVersion under the given context.
+func (ksm *KeyVaultSecretsManager) get(ctx context.Context, name, version string) (*Secret, *errors.Error) {
+	resp, err := ksm.readSecret(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &Secret{Name: name, redaction: ksm.kvClient.redactionStyle}
+	if resp.Value != nil {
+		if err := ksm.kvClient.checkUTF8(name, *resp.Value); err != nil {
+			return nil, err
+		}
+		secret.Value = *resp.Value
+	}
+	applyAttributes(secret, resp.Attributes)
+	secret.ContentType = derefString(resp.ContentType)
+	secret.Tags = fromAzTags(resp.Tags)
+	if resp.ID != nil {
+		secret.Version = resp.ID.Version()
+	}
+
+	return secret, nil
+}
+
+// readSecret is the read path shared by every getter returning a value: it
+// fetches the secret, applies WithMaxAge, decodes the value and reports new
+// versions to the hook set with WithVersionChangeHook.
+func (ksm *KeyVaultSecretsManager) readSecret(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, *errors.Error) {
+	resp, err := ksm.getSecret(ctx, name, version)
+	if err != nil {
+		return azsecrets.GetSecretResponse{}, err
+	}
+	if err := ksm.kvClient.checkMaxAge(name, resp.Attributes); err != nil {
+		return azsecrets.GetSecretResponse{}, err
+	}
+	if err := decodeValue(name, &resp); err != nil {
+		return azsecrets.GetSecretResponse{}, err
+	}
+	// Pinned reads say nothing about rotations.
+	if ksm.kvClient.versions != nil && version == "" && resp.ID != nil {
+		ksm.kvClient.versions.observe(name, resp.ID.Version())
+	}
+
+	return resp, nil
+}
+
+// getSecret fetches the given version of a secret, the latest one when
+// version is empty.
+func (ksm *KeyVaultSecretsManager) getSecret(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, *errors.Error) {
+	vaultName, err := ksm.kvClient.vaultSecretName(name)
+	if err != nil {
+		return azsecrets.GetSecretResponse{}, err
+	}
+
+	start := ksm.kvClient.now()
+	var resp azsecrets.GetSecretResponse
+	err = ksm.kvClient.retry(ctx, func() *errors.Error {
+		var err error
+		resp, err = ksm.secretsClient.GetSecret(ctx, vaultName, version, nil)
+		if err != nil {
+			return ksm.kvClient.azError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return azsecrets.GetSecretResponse{}, ksm.kvClient.operationError(ctx, opGetSecret, name, start, err)
+	}
+
+	return resp, nil
+}
+
+// Exists reports whether the secret with the given name exists. A missing
+// secret is not an error; any other failure is returned as is. It only reads
+// the version properties of the secret, so neither the value is fetched nor
+// does a disabled current version count as missing.
+func (ksm *KeyVaultSecretsManager) Exists(name string) (bool, *errors.Error) {
+	cache := ksm.kvClient.existence
+	if cache != nil {
+		if exists, ok := cache.get(name, ksm.kvClient.now()); ok {
+			return exists, nil
+		}
+	}
+
+	exists, err := ksm.hasVersion(ksm.kvClient.ctx, name)
+	if err != nil {
+		if err.Code != errors.ErrCodeNotFound {
+			return false, err
+		}
+		exists = false
+	}
+
+	if cache != nil {
+		cache.put(name, exists, ksm.kvClient.now())
+	}
+
+	return exists, nil
+}
+
+// hasVersion reports whether the secret with the given name has at least one
+// version, paging through its version properties no further than needed.
+func (ksm *KeyVaultSecretsManager) hasVersion(ctx context.Context, name string) (bool, *errors.Error) {
+	vaultName, err := ksm.kvClient.vaultSecretName(name)
+	if err != nil {
+		return false, err
+	}
+
+	start := ksm.kvClient.now()
+	pager := ksm.secretsClient.NewListSecretPropertiesVersionsPager(vaultName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return false, ksm.kvClient.operationError(ctx, opListSecretVersions, name, start, ksm.kvClient.azError(err))
+		}
+		for _, props := range page.Value {
+			if props != nil {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// invalidateExistence drops the cached presence of a secret changed by the
+// client.
+func (ksm *KeyVaultSecretsManager) invalidateExistence(name string) {
+	if ksm.kvClient.existence != nil {
+		ksm.kvClient.existence.invalidate(name)
+	}
+}
+
+// Set creates the secret, or adds a new version when it already exists. A
+// secret whose Expiration is in the past is a Validation error, unless
+// WithAllowExpired is set, and so are tags, including the default ones, that
+// set the x-kv-sdk-value-encoding tag this package reserves.
+func (ksm *KeyVaultSecretsManager) Set(secret Secret) *errors.Error {
+	return ksm.SetContext(ksm.kvClient.ctx, secret)
+}
+
+// SetContext is Set under ctx instead of the context of the client.
+func (ksm *KeyVaultSecretsManager) SetContext(ctx context.Context, secret Secret) *errors.Error {
+	ctx, end := ksm.kvClient.startOperation(ctx, spanSet, secret.Name)
+	_, err := ksm.set(ctx, secret)
+	end(err)
+	return err
+}
+
+// set is SetContext without its instrumentation. It returns the version it
+// created.
+func (ksm *KeyVaultSecretsManager) set(ctx context.Context, secret Secret) (string, *errors.Error) {
+	defer ksm.invalidateExistence(secret.Name)
+
+	name, err := ksm.kvClient.vaultSecretName(secret.Name)
+	if err != nil {
+		return "", err
+	}
+	if err := validateValidity(secret); err != nil {
+		return "", err
+	}
+	if err := ksm.kvClient.checkExpired(secret); err != nil {
+		return "", err
+	}
+	if err := ksm.kvClient.validateValue(secret); err != nil {
+		return "", err
+	}
+	if ksm.kvClient.warnDisabledSet && secret.IsEnabled() && ksm.isDisabled(ctx, secret.Name) {
+		ksm.kvClient.warn(opSetSecret, secret.Name, "current version is disabled, Set creates a new enabled version")
+	}
+
+	value := secret.Value
+	tags := mergeTags(ksm.kvClient.defaultTags, secret.Tags)
+	if err := checkReservedTags(secret.Name, tags); err != nil {
+		return "", err
+	}
+	if ksm.kvClient.compressValues {
+		if compressed, ok := compressValue(value); ok {
+			value = compressed
+			tags = maps.Clone(tags)
+			if tags == nil {
+				tags = map[string]string{}
+			}
+			tags[valueEncodingTag] = gzipEncoding
+		}
+	}
+
+	params := azsecrets.SetSecretParameters{
+		Value:            &value,
+		SecretAttributes: &azsecrets.SecretAttributes{},
+		Tags:             toAzTags(tags),
+	}
+	if secret.ContentType != "" {
+		params.ContentType = &secret.ContentType
+	}
+	if !secret.Expiration.IsZero() {
+		params.SecretAttributes.Expires = &secret.Expiration
+	}
+	if !secret.NotBefore.IsZero() {
+		params.SecretAttributes.NotBefore = &secret.NotBefore
+	}
+	if secret.Enabled != nil {
+		params.SecretAttributes.Enabled = secret.Enabled
+	}
+
+	var version string
+	start := ksm.kvClient.now()
+	err = ksm.kvClient.retry(ctx, func() *errors.Error {
+		resp, err := ksm.secretsClient.SetSecret(ctx, name, params, nil)
+		if err != nil {
+			return ksm.kvClient.azError(err)
+		}
+		if resp.ID != nil {
+			version = resp.ID.Version()
+		}
+		return nil
+	})
+	if err != nil {
+		// The error text comes from the service and the transport, which
+		// must not get to echo the value into logs.
+		err.Message = scrubValue(ksm.kvClient.redactionStyle, err.Message, secret.Value)
+	}
+
+	return version, ksm.kvClient.operationError(ctx, opSetSecret, secret.Name, start, err)
+}
+
+// SetValue sets the secret with the given name to value, expiring ttl from
+// now, or never when ttl is zero. A negative ttl is a Validation error.
+func (ksm *KeyVaultSecretsManager) SetValue(name, value string, ttl time.Duration) *errors.Error {
+	if ttl < 0 {
+		return errors.ValidationError(fmt.Sprintf("secret %q cannot expire %s ago", name, -ttl))
+	}
+
+	secret := Secret{Name: name, Value: value}
+	if ttl > 0 {
+		secret.Expiration = ksm.kvClient.now().Add(ttl)
+	}
+
+	return ksm.Set(secret)
+}
+
+// isDisabled reports whether the current version of the secret is known to
+// be disabled. It reads the version properties, the newest one being
+// current, so the value is never fetched. Failures to find out count as not
+// disabled.
+func (ksm *KeyVaultSecretsManager) isDisabled(ctx context.Context, name string) bool {
+	var current *azsecrets.SecretProperties
+	err := ksm.walkVersions(ctx, name, func(props *azsecrets.SecretProperties) {
+		if current == nil || createdOn(props).After(createdOn(current)) {
+			current = props
+		}
+	})
+	if err != nil || current == nil {
+		return false
+	}
+	return current.Attributes != nil && current.Attributes.Enabled != nil && !*current.Attributes.Enabled
+}
+
+// Delete deletes every version of the secret with the given name.
+func (ksm *KeyVaultSecretsManager) Delete(name string) *errors.Error {
+	return ksm.DeleteContext(ksm.kvClient.ctx, name)
+}
+
+// DeleteContext is Delete under ctx instead of the context of the client.
+func (ksm *KeyVaultSecretsManager) DeleteContext(ctx context.Context, name string) *errors.Error {
+	ctx, end := ksm.kvClient.startOperation(ctx, spanDelete, name)
+	err := ksm.deleteSecret(ctx, name)
+	end(err)
+	return err
+}
+
+// deleteSecret is DeleteContext without its instrumentation.
+func (ksm *KeyVaultSecretsManager) deleteSecret(ctx context.Context, name string) *errors.Error {
+	defer ksm.invalidateExistence(name)
+
+	vaultName, err := ksm.kvClient.vaultSecretName(name)
+	if err != nil {
+		return err
+	}
+
+	start := ksm.kvClient.now()
+	err = ksm.kvClient.retry(ctx, func() *errors.Error {
+		if _, err := ksm.secretsClient.DeleteSecret(ctx, vaultName, nil); err != nil {
+			return ksm.kvClient.azError(err)
+		}
+		return nil
+	})
+
+	return ksm.kvClient.operationError(ctx, opDeleteSecret, name, start, err)
+}
@@ -0,0 +1,145 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/tracing"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// KeyVaultClient is bound to a single KeyVault instance. The context given at
+// construction is used by every operation performed through it, except for
+// the Context variants of the manager operations, which take their own.
+type KeyVaultClient struct {
+	ctx              context.Context
+	vaultName        string
+	vaultURL         string
+	apiVersion       string
+	insecureVaultURL bool
+	secretsClient    *azsecrets.Client
+
+	nameAllowlist   []string
+	nameDenylist    []string
+	toVaultName     func(string) string
+	fromVaultName   func(string) string
+	observer        Observer
+	redactTraceID   bool
+	redactionStyle  RedactionStyle
+	maxAge          time.Duration
+	maxAgeMode      MaxAgeMode
+	invalidUTF8Mode InvalidUTF8Mode
+	valueValidator  func(name, value string) error
+	clock           func() time.Time
+	retryPolicy     RetryPolicy
+	retryHook       RetryHook
+	retryPredicate  RetryPredicate
+	maxRetryDelay   time.Duration
+	eagerAuth       bool
+	operationPrefix string
+	existence       *existenceCache
+	defaultTags     map[string]string
+	warnDisabledSet bool
+	includeDeleted  bool
+	compressValues  bool
+	allowExpired    bool
+	versions        *versionTracker
+	resolver        Resolver
+	tracer          tracing.Tracer
+	logger          *slog.Logger
+}
+
+// now returns the current time from the clock of the client.
+func (kvc *KeyVaultClient) now() time.Time {
+	if kvc.clock != nil {
+		return kvc.clock()
+	}
+	return time.Now()
+}
+
+// Raw returns the azsecrets client the client talks to the vault with, for
+// operations this package does not cover. Calls made through it bypass the
+// name scoping, retries, instrumentation and error mapping of this package:
+// they return the Azure SDK errors, not *errors.Error.
+func (kvc *KeyVaultClient) Raw() *azsecrets.Client {
+	return kvc.secretsClient
+}
+
+// Close releases what the client holds. The client starts no goroutines of
+// its own and its connections are pooled by the HTTP transport, so Close only
+// drops its caches for now; it gives cleanup a place to hang on later. It is
+// idempotent and safe for concurrent use, and always returns nil.
+func (kvc *KeyVaultClient) Close() error {
+	if kvc.existence != nil {
+		kvc.existence.clear()
+	}
+	return nil
+}
+
+// NewKeyVaultClient creates a KeyVaultClient for the vault with the given
+// name in the cloud of client, or at the URL set with WithVaultURL,
+// authenticated with the credential of client.
+func NewKeyVaultClient(ctx context.Context, client *Client, vaultName string, opts ...KeyVaultClientOption) (*KeyVaultClient, *errors.Error) {
+	kvClient := &KeyVaultClient{
+		ctx:       ctx,
+		vaultName: vaultName,
+	}
+	for _, opt := range opts {
+		opt(kvClient)
+	}
+
+	cloud := client.cloud
+	if cloud.VaultDNSSuffix == "" {
+		cloud = AzurePublic
+	}
+	vaultURL := kvClient.vaultURL
+	if vaultURL == "" {
+		if err := validateVaultName(vaultName); err != nil {
+			return nil, err
+		}
+		vaultURL = cloud.vaultURL(vaultName)
+	} else if err := validateVaultURL(vaultURL, kvClient.insecureVaultURL); err != nil {
+		return nil, err
+	}
+	kvClient.vaultURL = vaultURL
+
+	secretsClient, err := azsecrets.NewClient(vaultURL, client.credential, client.secretsClientOptions(kvClient.insecureVaultURL))
+	if err != nil {
+		return nil, errors.InternalServerError(fmt.Sprintf("failed to create secrets client for %s: %v", vaultURL, err))
+	}
+	kvClient.secretsClient = secretsClient
+	kvClient.apiVersion = client.apiVersion
+	kvClient.tracer = client.options.TracingProvider.NewTracer(tracerName, Version)
+	kvClient.logger = client.logger
+
+	if kvClient.eagerAuth {
+		_, err := client.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{cloud.scope()}})
+		if err != nil {
+			return nil, errors.UnauthorizedError(fmt.Sprintf("failed to acquire a token for %s: %v", vaultURL, err))
+		}
+	}
+
+	return kvClient, nil
+}
+
+// validateVaultURL checks that a vault URL set with WithVaultURL is an
+// absolute https URL, or http one when insecure is set.
+func validateVaultURL(vaultURL string, insecure bool) *errors.Error {
+	u, err := url.Parse(vaultURL)
+	if err != nil || u.Host == "" {
+		return errors.ValidationError(fmt.Sprintf("invalid vault URL %q: must be an absolute URL", vaultURL))
+	}
+	switch {
+	case u.Scheme == "https":
+	case u.Scheme == "http" && insecure:
+	default:
+		return errors.ValidationError(fmt.Sprintf("invalid vault URL %q: scheme must be https", vaultURL))
+	}
+	return nil
+}
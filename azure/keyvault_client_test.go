@@ -0,0 +1,58 @@
+package azure
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestEager(t *testing.T) {
+	c := &fakeCred{err: io.EOF}
+	if _, err := NewKeyVaultClient(context.Background(), &Client{credential: c}, "vlt"); err != nil || c.calls != 0 {
+		t.Fatal(err)
+	}
+	if _, err := NewKeyVaultClient(context.Background(), &Client{credential: c}, "vlt", WithEagerAuth(true)); err == nil || err.Code != errors.ErrCodeUnauthorized {
+		t.Fatal(err)
+	}
+	c.err = nil
+	if _, err := NewKeyVaultClient(context.Background(), &Client{credential: c}, "vlt", WithEagerAuth(true)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVaultURL(t *testing.T) {
+	c := &Client{credential: &fakeCred{}}
+	kvc, err := NewKeyVaultClient(context.Background(), c, "vlt", WithVaultURL("https://kv.internal:8443"))
+	if err != nil || kvc.vaultURL != "https://kv.internal:8443" {
+		t.Fatal(err)
+	}
+	if _, err := NewKeyVaultClient(context.Background(), c, "vlt", WithVaultURL("http://localhost:8080")); err == nil || err.Code != errors.ErrCodeValidation {
+		t.Fatal(err)
+	}
+	if _, err := NewKeyVaultClient(context.Background(), c, "vlt", WithVaultURL("localhost")); err == nil {
+		t.Fatal()
+	}
+	if _, err := NewKeyVaultClient(context.Background(), c, "vlt", WithVaultURL("http://localhost:8080"), WithInsecureVaultURL(true)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	kvc, _ := NewKeyVaultClient(context.Background(), &Client{credential: &fakeCred{}}, "vlt", WithExistenceCache(time.Minute))
+	if kvc.Close() != nil || kvc.Close() != nil {
+		t.Fatal()
+	}
+	if (&KeyVaultClient{}).Close() != nil {
+		t.Fatal()
+	}
+}
+
+func TestRawClient(t *testing.T) {
+	kvc, _ := NewKeyVaultClient(context.Background(), &Client{credential: &fakeCred{}}, "vlt")
+	if kvc.Raw() == nil || kvc.Raw() != kvc.secretsClient {
+		t.Fatal()
+	}
+}
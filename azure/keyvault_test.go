@@ -0,0 +1,416 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestNilID(t *testing.T) {
+	p := props("a")
+	p = append(p, &azsecrets.SecretProperties{}, nil)
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{p}}
+	ksm := newTestManager(context.Background(), f)
+	o := &recObs{}
+	ksm.kvClient.observer = o
+	l, err := ksm.List()
+	if err != nil || len(l) != 1 || len(o.msgs) != 2 {
+		t.Fatal(l, err, o.msgs)
+	}
+}
+
+func TestExists(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		t.Fatal("value fetched")
+		return azsecrets.GetSecretResponse{}, nil
+	}, versions: func(name string) ([][]*azsecrets.SecretProperties, error) {
+		switch name {
+		case "a":
+			return [][]*azsecrets.SecretProperties{props("a/v1")}, nil
+		case "f":
+			return nil, respErr(403, "", nil)
+		case "e":
+			return nil, nil
+		}
+		return nil, respErr(404, "", nil)
+	}}
+	var r ISecretReader = newTestManager(context.Background(), f)
+	if ok, err := r.Exists("a"); !ok || err != nil {
+		t.Fatal()
+	}
+	if ok, err := r.Exists("b"); ok || err != nil {
+		t.Fatal()
+	}
+	if ok, err := r.Exists("f"); ok || err == nil || err.Status != 403 {
+		t.Fatal()
+	}
+	if ok, err := r.Exists("e"); ok || err != nil {
+		t.Fatal()
+	}
+}
+
+func TestTags(t *testing.T) {
+	var got azsecrets.SetSecretParameters
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		got = p
+		return azsecrets.SetSecretResponse{}, nil
+	}, getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Tags: got.Tags}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.Set(Secret{Name: "a"})
+	if got.Tags != nil {
+		t.Fatal("empty tags sent")
+	}
+	WithDefaultTags(map[string]string{"owner": "x", "env": "dev"})(ksm.kvClient)
+	ksm.Set(Secret{Name: "a", Tags: map[string]string{"env": "prod"}})
+	s, _ := ksm.Get("a")
+	if len(s.Tags) != 2 || s.Tags["env"] != "prod" || s.Tags["owner"] != "x" {
+		t.Fatal(s.Tags)
+	}
+	m := newCountingStore(Secret{Name: "a", Tags: map[string]string{"k": "v"}})
+	cs := NewCachedSecrets(m, time.Minute)
+	c1, _ := cs.Get("a")
+	c1.Tags["k"] = "mut"
+	c2, _ := cs.Get("a")
+	if c2.Tags["k"] != "v" {
+		t.Fatal("alias")
+	}
+}
+
+func TestDisabledSet(t *testing.T) {
+	older, newer := time.Unix(100, 0), time.Unix(200, 0)
+	current := false
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		t.Error("value fetched to check the current version")
+		return azsecrets.GetSecretResponse{}, nil
+	}, setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		return azsecrets.SetSecretResponse{}, nil
+	}, versions: func(name string) ([][]*azsecrets.SecretProperties, error) {
+		vs := props(name+"/v1", name+"/v2")
+		v1, v2 := vs[0], vs[1]
+		v1.Attributes = &azsecrets.SecretAttributes{Created: &older, Enabled: to.Ptr(true)}
+		v2.Attributes = &azsecrets.SecretAttributes{Created: &newer, Enabled: to.Ptr(current)}
+		return [][]*azsecrets.SecretProperties{{v2, v1}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	o := &recObs{}
+	ksm.kvClient.observer = o
+	ksm.Set(Secret{Name: "a"})
+	if len(o.msgs) != 0 {
+		t.Fatal()
+	}
+	ksm.kvClient.warnDisabledSet = true
+	if err := ksm.Set(Secret{Name: "a"}); err != nil || len(o.msgs) != 1 {
+		t.Fatal(err, o.msgs)
+	}
+	current = true
+	if err := ksm.Set(Secret{Name: "a"}); err != nil || len(o.msgs) != 1 {
+		t.Fatal(err, o.msgs)
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	var seen context.Context
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		seen = ctx
+		if ctx.Err() != nil {
+			return azsecrets.GetSecretResponse{}, ctx.Err()
+		}
+		return azsecrets.GetSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, 1))
+	view := ksm.WithContext(ctx)
+	if _, err := view.Get("a"); err != nil || seen.Value(ctxKey{}) != 1 {
+		t.Fatal(err)
+	}
+	cancel()
+	if _, err := view.Get("a"); err == nil {
+		t.Fatal("want err")
+	}
+	if _, err := ksm.Get("a"); err != nil || seen.Value(ctxKey{}) != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	conformance(t, NewMemoryStore())
+	store := map[string]azsecrets.SetSecretParameters{}
+	f := &fakeOps{}
+	f.setSecret = func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		store[name] = p
+		f.listPages = [][]*azsecrets.SecretProperties{props(name)}
+		return azsecrets.SetSecretResponse{}, nil
+	}
+	f.getSecret = func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		p, ok := store[name]
+		if !ok {
+			return azsecrets.GetSecretResponse{}, respErr(404, "", nil)
+		}
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: p.Value, Tags: p.Tags}}, nil
+	}
+	f.deleteSecret = func(ctx context.Context, name string) (azsecrets.DeleteSecretResponse, error) {
+		delete(store, name)
+		return azsecrets.DeleteSecretResponse{}, nil
+	}
+	f.versions = func(name string) ([][]*azsecrets.SecretProperties, error) {
+		if _, ok := store[name]; !ok {
+			return nil, nil
+		}
+		return [][]*azsecrets.SecretProperties{props(name + "/v1")}, nil
+	}
+	conformance(t, newTestManager(context.Background(), f))
+}
+
+func TestGetVersion(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		if version == "" {
+			version = "latest1"
+		} else if version != "old1" {
+			return azsecrets.GetSecretResponse{}, respErr(404, `{"error":{"message":"version not found"}}`, nil)
+		}
+		id := azsecrets.ID("https://vlt.vault.azure.net/secrets/" + name + "/" + version)
+		v := "val-" + version
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{ID: &id, Value: &v}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	s, err := ksm.GetVersion("a", "old1")
+	if err != nil || s.Version != "old1" || s.Value != "val-old1" {
+		t.Fatal(s, err)
+	}
+	if s, _ := ksm.Get("a"); s.Version != "latest1" {
+		t.Fatal(s)
+	}
+	if _, err := ksm.GetVersion("a", "nope"); err == nil || err.Code != errors.ErrCodeNotFound {
+		t.Fatal(err)
+	}
+}
+
+func TestContentType(t *testing.T) {
+	var sent *string
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		sent = p.ContentType
+		return azsecrets.SetSecretResponse{}, nil
+	}, getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{ContentType: sent}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.Set(Secret{Name: "a"})
+	if s, _ := ksm.Get("a"); sent != nil || s.ContentType != "" {
+		t.Fatal(sent)
+	}
+	ksm.Set(Secret{Name: "a", ContentType: "application/json"})
+	if s, _ := ksm.Get("a"); s.ContentType != "application/json" {
+		t.Fatal(s)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	var sent *bool
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		sent = p.SecretAttributes.Enabled
+		return azsecrets.SetSecretResponse{}, nil
+	}, getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		f := false
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Attributes: &azsecrets.SecretAttributes{Enabled: &f}}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.Set(Secret{Name: "a"})
+	if sent != nil {
+		t.Fatal(*sent)
+	}
+	no := false
+	ksm.Set(Secret{Name: "a", Enabled: &no})
+	if sent == nil || *sent {
+		t.Fatal(sent)
+	}
+	if s, _ := ksm.Get("a"); s.IsEnabled() {
+		t.Fatal(s)
+	}
+	if !(Secret{}).IsEnabled() || !EqualIgnoringMeta(Secret{}, Secret{Enabled: to.Ptr(true)}) {
+		t.Fatal("default")
+	}
+}
+
+func TestPerCallContext(t *testing.T) {
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{props("a"), props("b")}, getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		<-ctx.Done()
+		return azsecrets.GetSecretResponse{}, ctx.Err()
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ksm.ListContext(ctx); err == nil || f.pagesFetched != 0 {
+		t.Fatal(err, f.pagesFetched)
+	}
+	if l, err := ksm.List(); err != nil || len(l) != 2 {
+		t.Fatal(l, err)
+	}
+	tctx, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	if _, err := ksm.GetContext(tctx, "a"); err == nil || err.Code != errors.ErrCodeTimeout {
+		t.Fatal(err)
+	}
+}
+
+func TestGetValue(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		switch name {
+		case "a":
+			v := "conn"
+			return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: &v}}, nil
+		case "empty":
+			return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("")}}, nil
+		case "d":
+			return azsecrets.GetSecretResponse{}, respErr(403, `{"error":{"innererror":{"code":"SecretDisabled"}}}`, nil)
+		}
+		return azsecrets.GetSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	if v, err := ksm.GetValue("a"); v != "conn" || err != nil {
+		t.Fatal(v, err)
+	}
+	if _, err := ksm.GetValue("nil"); err == nil || err.Code != errors.ErrCodeNotFound {
+		t.Fatal(err)
+	}
+	if v, err := ksm.GetValue("empty"); v != "" || err != nil {
+		t.Fatal(v, err)
+	}
+	if _, err := ksm.GetValue("d"); err == nil || err.Code != errors.ErrCodeSecretDisabled {
+		t.Fatal(err)
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	var got azsecrets.SetSecretParameters
+	calls := 0
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		got = p
+		calls++
+		return azsecrets.SetSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	if err := ksm.SetValue("a", "v", 90*24*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Until(*got.SecretAttributes.Expires) - 90*24*time.Hour; d > time.Second || d < -time.Second {
+		t.Fatal(d)
+	}
+	ksm.SetValue("a", "v", 0)
+	if got.SecretAttributes.Expires != nil {
+		t.Fatal("expires")
+	}
+	if err := ksm.SetValue("a", "v", -time.Hour); err == nil || err.Code != errors.ErrCodeValidation || calls != 2 {
+		t.Fatal(err)
+	}
+}
+
+func TestTimestamps(t *testing.T) {
+	created, updated := time.Unix(100, 0), time.Unix(200, 0)
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		id := azsecrets.ID("https://vlt.vault.azure.net/secrets/db/v7")
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{ID: &id, Value: to.Ptr("x"), Attributes: &azsecrets.SecretAttributes{Created: &created, Updated: &updated}}}, nil
+	}}
+	s, err := newTestManager(context.Background(), f).Get("db")
+	if err != nil || s.Version != "v7" || !s.CreatedOn.Equal(created) || !s.UpdatedOn.Equal(updated) {
+		t.Fatal(s, err)
+	}
+	f.getSecret = func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: to.Ptr("x"), Attributes: &azsecrets.SecretAttributes{}}}, nil
+	}
+	if s, _ := newTestManager(context.Background(), f).Get("db"); !s.CreatedOn.IsZero() {
+		t.Fatal(s)
+	}
+}
+
+func TestNoValueLeak(t *testing.T) {
+	s := Secret{Name: "db", Value: "hunter2"}
+	for _, f := range []string{"%v", "%+v", "%#v", "%s"} {
+		if strings.Contains(fmt.Sprintf(f, s), "hunter2") || strings.Contains(fmt.Sprintf(f, &s), "hunter2") {
+			t.Fatal(f)
+		}
+	}
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		return azsecrets.SetSecretResponse{}, respErr(400, `{"error":{"message":"bad value hunter2"}}`, nil)
+	}}
+	ksm := newTestManager(context.Background(), f)
+	if err := ksm.Set(s); err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Fatal(err)
+	}
+	ksm.kvClient.valueValidator = func(name, value string) error { return fmt.Errorf("%s is too short", value) }
+	if err := ksm.Set(s); err == nil || strings.Contains(err.Error(), "hunter2") || !strings.Contains(err.Message, "<redacted> is too short") {
+		t.Fatal(err)
+	}
+}
+
+func TestNilExpiresAndValue(t *testing.T) {
+	id := azsecrets.ID("https://vlt.vault.azure.net/secrets/a/v1")
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{ID: &id}}, nil
+	}, listPages: [][]*azsecrets.SecretProperties{{nil, {ID: &id}}}}
+	ksm := newTestManager(context.Background(), f)
+	s, err := ksm.Get("a")
+	if err != nil || s.Value != "" || !s.Expiration.IsZero() || !s.NotBefore.IsZero() {
+		t.Fatal(s, err)
+	}
+	l, err := ksm.List()
+	if err != nil || len(l) != 1 || l[0].Name != "a" || !l[0].Expiration.IsZero() {
+		t.Fatal(l, err)
+	}
+}
+
+func TestSetParameters(t *testing.T) {
+	var got azsecrets.SetSecretParameters
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		got = p
+		return azsecrets.SetSecretResponse{}, nil
+	}}
+	nbf := time.Now().Add(time.Hour).Truncate(time.Second)
+	exp := nbf.Add(24 * time.Hour)
+	err := newTestManager(context.Background(), f).Set(Secret{Name: "a", Value: "v", NotBefore: nbf, Expiration: exp, Tags: map[string]string{"k": "v"}})
+	if err != nil || *got.Value != "v" || !got.SecretAttributes.Expires.Equal(exp) || !got.SecretAttributes.NotBefore.Equal(nbf) || *got.Tags["k"] != "v" {
+		t.Fatal(err, got)
+	}
+}
+
+func TestSetDeleteForbidden(t *testing.T) {
+	forbidden := respErr(403, `{"error":{"code":"Forbidden","message":"denied","innererror":{"code":"ForbiddenByPolicy"}}}`, nil)
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		return azsecrets.SetSecretResponse{}, forbidden
+	}, deleteSecret: func(ctx context.Context, name string) (azsecrets.DeleteSecretResponse, error) {
+		return azsecrets.DeleteSecretResponse{}, forbidden
+	}}
+	ksm := newTestManager(context.Background(), f)
+	if err := ksm.Set(Secret{Name: "a", Value: "v"}); err == nil || err.Code != errors.ErrCodeInsufficientAccess {
+		t.Fatal(err)
+	}
+	if err := ksm.Delete("a"); err == nil || err.Code != errors.ErrCodeInsufficientAccess {
+		t.Fatal(err)
+	}
+}
+
+func TestTagsRoundTrip(t *testing.T) {
+	tags := map[string]string{"env": "prod", "empty": ""}
+	got := fromAzTags(toAzTags(tags))
+	if len(got) != 2 || got["env"] != "prod" || got["empty"] != "" {
+		t.Fatal(got)
+	}
+	if toAzTags(nil) != nil || fromAzTags(nil) != nil {
+		t.Fatal("no tags should stay nil")
+	}
+	f := vaultFake()
+	ksm := newTestManager(context.Background(), f)
+	ksm.Set(Secret{Name: "a", Value: "v", Tags: tags})
+	s, err := ksm.Get("a")
+	if err != nil || len(s.Tags) != 2 || s.Tags["env"] != "prod" {
+		t.Fatal(s, err)
+	}
+}
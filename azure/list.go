@@ -0,0 +1,157 @@
+package azure
+
+import (
+	"context"
+	"iter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// walkProperties pages through the secret properties of the vault and calls
+// yield with those of every secret the client is allowed to see, until yield
+// returns false. Entries without an ID are skipped and reported to the
+// observer.
+func (ksm *KeyVaultSecretsManager) walkProperties(ctx context.Context, yield func(*azsecrets.SecretProperties) bool) *errors.Error {
+	start := ksm.kvClient.now()
+	pager := ksm.secretsClient.NewListSecretPropertiesPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return ksm.kvClient.operationError(ctx, opListSecrets, "", start, ksm.kvClient.azError(err))
+		}
+
+		for _, props := range page.Value {
+			if props == nil || props.ID == nil {
+				ksm.kvClient.warn(opListSecrets, "", "skipped secret properties without an ID")
+				continue
+			}
+			if !ksm.kvClient.nameAllowed(props.ID.Name()) {
+				continue
+			}
+			if !yield(props) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkSecrets is walkProperties yielding the mapped secrets, named as the
+// caller knows them.
+func (ksm *KeyVaultSecretsManager) walkSecrets(ctx context.Context, yield func(Secret) bool) *errors.Error {
+	return ksm.walkProperties(ctx, func(props *azsecrets.SecretProperties) bool {
+		secret := secretFromProperties(props)
+		secret.Name = ksm.kvClient.callerSecretName(secret.Name)
+		return yield(secret)
+	})
+}
+
+// walkDeletedSecrets is walkSecrets for the soft-deleted secrets of the
+// vault, which it yields flagged as Deleted.
+func (ksm *KeyVaultSecretsManager) walkDeletedSecrets(ctx context.Context, yield func(Secret) bool) *errors.Error {
+	start := ksm.kvClient.now()
+	pager := ksm.secretsClient.NewListDeletedSecretPropertiesPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return ksm.kvClient.operationError(ctx, opListDeletedSecrets, "", start, ksm.kvClient.azError(err))
+		}
+
+		for _, props := range page.Value {
+			if props == nil || props.ID == nil {
+				ksm.kvClient.warn(opListDeletedSecrets, "", "skipped deleted secret properties without an ID")
+				continue
+			}
+			if !ksm.kvClient.nameAllowed(props.ID.Name()) {
+				continue
+			}
+
+			secret := Secret{Name: ksm.kvClient.callerSecretName(props.ID.Name()), Deleted: true}
+			applyAttributes(&secret, props.Attributes)
+			secret.ContentType = derefString(props.ContentType)
+			secret.Tags = fromAzTags(props.Tags)
+			applyDeletion(&secret, props.DeletedDate, props.ScheduledPurgeDate)
+			if !yield(secret) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// ListRawProperties returns the unmapped properties of every secret in the
+// vault, for fields Secret does not carry. It applies the same name scoping,
+// context handling and error mapping as List, but the IDs keep the vault
+// names.
+func (ksm *KeyVaultSecretsManager) ListRawProperties(ctx context.Context) ([]azsecrets.SecretProperties, *errors.Error) {
+	var properties []azsecrets.SecretProperties
+
+	err := ksm.walkProperties(ctx, func(props *azsecrets.SecretProperties) bool {
+		properties = append(properties, *props)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return properties, nil
+}
+
+// secretFromProperties maps secret properties returned by a list operation.
+func secretFromProperties(props *azsecrets.SecretProperties) Secret {
+	secret := Secret{Name: props.ID.Name(), Version: props.ID.Version()}
+	applyAttributes(&secret, props.Attributes)
+	secret.ContentType = derefString(props.ContentType)
+	secret.Tags = fromAzTags(props.Tags)
+	return secret
+}
+
+// ListIter yields the secrets of the vault, without values, as their pages
+// arrive. Breaking out of the loop stops paging before the next page is
+// fetched. A failure is yielded once with a zero Secret and ends the
+// iteration.
+func (ksm *KeyVaultSecretsManager) ListIter(ctx context.Context) iter.Seq2[Secret, *errors.Error] {
+	return func(yield func(Secret, *errors.Error) bool) {
+		if err := ksm.walkSecrets(ctx, func(secret Secret) bool {
+			return yield(secret, nil)
+		}); err != nil {
+			yield(Secret{}, err)
+		}
+	}
+}
+
+// ListChan pages through the secrets of the vault in a goroutine and sends
+// them, without values, on the returned channel. Both channels are closed
+// once paging stops. Paging stops on the first failure, which is sent on the
+// error channel, or when ctx is done, in which case the context error is
+// sent.
+func (ksm *KeyVaultSecretsManager) ListChan(ctx context.Context) (<-chan Secret, <-chan *errors.Error) {
+	secrets := make(chan Secret)
+	errs := make(chan *errors.Error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(secrets)
+
+		err := ksm.walkSecrets(ctx, func(secret Secret) bool {
+			select {
+			case secrets <- secret:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if err == nil && ctx.Err() != nil {
+			err = ksm.kvClient.azError(ctx.Err())
+		}
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return secrets, errs
+}
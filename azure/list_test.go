@@ -0,0 +1,90 @@
+package azure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestListChan(t *testing.T) {
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{props("a", "b"), props("c")}}
+	ksm := newTestManager(context.Background(), f)
+	s, e := ksm.ListChan(context.Background())
+	n := 0
+	for range s {
+		n++
+	}
+	if n != 3 || <-e != nil {
+		t.Fatal(n)
+	}
+	f2 := &fakeOps{listPages: [][]*azsecrets.SecretProperties{props("a")}, listErr: respErr(403, "", nil)}
+	s, e = newTestManager(context.Background(), f2).ListChan(context.Background())
+	for range s {
+	}
+	if err := <-e; err == nil || err.Status != 403 {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s, e = ksm.ListChan(ctx)
+	<-s
+	cancel()
+	for range s {
+	}
+	if err := <-e; err == nil {
+		t.Fatal("want cancel")
+	}
+}
+
+func TestRaw(t *testing.T) {
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{props("a", "b"), props("c")}}
+	r, err := newTestManager(context.Background(), f).ListRawProperties(context.Background())
+	if err != nil || len(r) != 3 || r[2].ID.Name() != "c" {
+		t.Fatal(r, err)
+	}
+}
+
+func TestIncludeDeleted(t *testing.T) {
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{props("a")}, deletedPages: [][]*azsecrets.DeletedSecretProperties{dprops("d")}}
+	ksm := newTestManager(context.Background(), f)
+	l, _ := ksm.List()
+	if len(l) != 1 {
+		t.Fatal(l)
+	}
+	f.listPages = [][]*azsecrets.SecretProperties{props("a")}
+	ksm.kvClient.includeDeleted = true
+	l, _ = ksm.List()
+	if len(l) != 2 || !l[1].Deleted || l[0].Deleted {
+		t.Fatal(l)
+	}
+}
+
+func TestListIter(t *testing.T) {
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{props("a", "b"), props("c")}}
+	ksm := newTestManager(context.Background(), f)
+	for s, err := range ksm.ListIter(context.Background()) {
+		if err != nil || s.Name != "a" {
+			t.Fatal(s, err)
+		}
+		break
+	}
+	if f.pagesFetched != 1 {
+		t.Fatal(f.pagesFetched)
+	}
+	var names []string
+	for s, err := range ksm.ListIter(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, s.Name)
+	}
+	if len(names) != 3 {
+		t.Fatal(names)
+	}
+	f.listErr = respErr(503, "", nil)
+	for _, err := range ksm.ListIter(context.Background()) {
+		if err == nil {
+			t.Fatal()
+		}
+	}
+}
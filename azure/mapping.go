@@ -0,0 +1,102 @@
+package azure
+
+import (
+	"maps"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+// applyAttributes copies the attributes KeyVault returned for a secret into
+// secret, leaving the zero value for those it did not return.
+func applyAttributes(secret *Secret, attrs *azsecrets.SecretAttributes) {
+	if attrs == nil {
+		return
+	}
+	if attrs.Expires != nil {
+		secret.Expiration = *attrs.Expires
+	}
+	if attrs.NotBefore != nil {
+		secret.NotBefore = *attrs.NotBefore
+	}
+	if attrs.Created != nil {
+		secret.CreatedOn = *attrs.Created
+	}
+	if attrs.Updated != nil {
+		secret.UpdatedOn = *attrs.Updated
+	}
+	if attrs.Enabled != nil {
+		enabled := *attrs.Enabled
+		secret.Enabled = &enabled
+	}
+}
+
+// applyDeletion copies the deletion dates KeyVault returned for a deleted
+// secret into secret.
+func applyDeletion(secret *Secret, deletedDate, scheduledPurgeDate *time.Time) {
+	if deletedDate != nil {
+		secret.DeletedDate = *deletedDate
+	}
+	if scheduledPurgeDate != nil {
+		secret.ScheduledPurgeDate = *scheduledPurgeDate
+	}
+}
+
+// derefString returns the string s points to, or the empty string when s is
+// nil.
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// fromAzTags converts KeyVault tags, whose values are pointers, into a plain
+// map. Nil values become empty strings and the value encoding marker is left
+// out; no tags give a nil map.
+func fromAzTags(tags map[string]*string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if k == valueEncodingTag {
+			continue
+		}
+		if v != nil {
+			out[k] = *v
+		} else {
+			out[k] = ""
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// toAzTags converts tags into the form KeyVault expects. No tags give a nil
+// map, so that no empty object is sent.
+func toAzTags(tags map[string]string) map[string]*string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	out := make(map[string]*string, len(tags))
+	for k, v := range tags {
+		out[k] = &v
+	}
+	return out
+}
+
+// mergeTags returns the defaults overridden by tags, as a new map.
+func mergeTags(defaults, tags map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return tags
+	}
+
+	merged := maps.Clone(defaults)
+	maps.Copy(merged, tags)
+	return merged
+}
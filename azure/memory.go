@@ -0,0 +1,95 @@
+package azure
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+var _ SecretStore = (*MemoryStore)(nil)
+
+// MemoryStore is a SecretStore that keeps secrets in memory, for tests and
+// local development. It follows the semantics of KeyVaultSecretsManager:
+// List leaves values out and missing secrets are NotFound errors. It is safe
+// for concurrent use.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	secrets map[string]Secret
+}
+
+// NewMemoryStore creates a MemoryStore holding the given secrets.
+func NewMemoryStore(secrets ...Secret) *MemoryStore {
+	m := &MemoryStore{secrets: make(map[string]Secret, len(secrets))}
+	for _, secret := range secrets {
+		m.secrets[secret.Name] = secret.clone()
+	}
+	return m
+}
+
+// List returns every secret of the store sorted by name, without values.
+func (m *MemoryStore) List() ([]Secret, *errors.Error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	secrets := make([]Secret, 0, len(m.secrets))
+	for _, secret := range m.secrets {
+		secret = secret.clone()
+		secret.Value = ""
+		secrets = append(secrets, secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	return secrets, nil
+}
+
+// Get returns the secret with the given name.
+func (m *MemoryStore) Get(name string) (*Secret, *errors.Error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	secret, ok := m.secrets[name]
+	if !ok {
+		return nil, errors.NotFoundError(fmt.Sprintf("secret %q not found", name))
+	}
+	secret = secret.clone()
+
+	return &secret, nil
+}
+
+// Exists reports whether the secret with the given name exists.
+func (m *MemoryStore) Exists(name string) (bool, *errors.Error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.secrets[name]
+	return ok, nil
+}
+
+// Set creates or replaces the secret.
+func (m *MemoryStore) Set(secret Secret) *errors.Error {
+	if err := validateValidity(secret); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.secrets[secret.Name] = secret.clone()
+	return nil
+}
+
+// Delete deletes the secret with the given name.
+func (m *MemoryStore) Delete(name string) *errors.Error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.secrets[name]; !ok {
+		return errors.NotFoundError(fmt.Sprintf("secret %q not found", name))
+	}
+	delete(m.secrets, name)
+	return nil
+}
@@ -0,0 +1,121 @@
+package azure
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// MigrateOptions controls Migrate.
+type MigrateOptions struct {
+	// Concurrency is the number of secrets copied in parallel. Values below
+	// 1 copy one secret at a time.
+	Concurrency int
+	// Overwrite replaces the secrets that already exist in the destination.
+	// They are skipped otherwise.
+	Overwrite bool
+	// DryRun computes the report without writing to the destination.
+	DryRun bool
+}
+
+// MigrateReport lists the outcome of Migrate for every secret of the source.
+// In a dry run, Copied lists the secrets that would have been copied.
+type MigrateReport struct {
+	Copied  []string
+	Skipped []string
+	Failed  map[string]*errors.Error
+}
+
+// Migrate copies every secret of src into dst, with the attributes both
+// support. src and dst can be any IKeyVaultSecret, so this moves secrets
+// between two vaults as well as between a vault and a local implementation.
+// Soft-deleted secrets listed by src are left out. Stores with a WithContext
+// method, like KeyVaultSecretsManager, are used under ctx.
+//
+// A failure on one secret is recorded in the report and does not stop the
+// others. The returned error is set when the source cannot be listed or ctx
+// is done before every secret was handled.
+func Migrate(ctx context.Context, src, dst IKeyVaultSecret, opts MigrateOptions) (MigrateReport, *errors.Error) {
+	report := MigrateReport{Failed: make(map[string]*errors.Error)}
+	src, dst = withContext(ctx, src), withContext(ctx, dst)
+
+	secrets, err := src.List()
+	if err != nil {
+		return report, err
+	}
+
+	var names []string
+	for _, secret := range secrets {
+		if !secret.Deleted {
+			names = append(names, secret.Name)
+		}
+	}
+
+	var mu sync.Mutex
+	sent := fanOut(ctx, len(names), opts.Concurrency, func(i int) {
+		copied, err := migrateSecret(src, dst, names[i], opts)
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case err != nil:
+			report.Failed[names[i]] = err
+		case copied:
+			report.Copied = append(report.Copied, names[i])
+		default:
+			report.Skipped = append(report.Skipped, names[i])
+		}
+	})
+
+	sort.Strings(report.Copied)
+	sort.Strings(report.Skipped)
+
+	if sent < len(names) {
+		return report, checkAzErrResp(ctx.Err())
+	}
+	return report, nil
+}
+
+// contextStore is implemented by the stores whose operations can be bound to
+// a context, like KeyVaultSecretsManager.
+type contextStore interface {
+	WithContext(ctx context.Context) IKeyVaultSecret
+}
+
+// withContext returns store bound to ctx when it supports it, and store as
+// is otherwise.
+func withContext(ctx context.Context, store IKeyVaultSecret) IKeyVaultSecret {
+	if cs, ok := store.(contextStore); ok {
+		return cs.WithContext(ctx)
+	}
+	return store
+}
+
+// migrateSecret copies a single secret and reports whether it was, or in a
+// dry run would have been, copied.
+func migrateSecret(src, dst IKeyVaultSecret, name string, opts MigrateOptions) (bool, *errors.Error) {
+	if !opts.Overwrite {
+		exists, err := dst.Exists(name)
+		if err != nil {
+			return false, err
+		}
+		if exists {
+			return false, nil
+		}
+	}
+
+	secret, err := src.Get(name)
+	if err != nil {
+		return false, err
+	}
+	if opts.DryRun {
+		return true, nil
+	}
+	if err := dst.Set(*secret); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
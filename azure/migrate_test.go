@@ -0,0 +1,63 @@
+package azure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestMigrate(t *testing.T) {
+	src := newCountingStore(Secret{Name: "a", Value: "1"}, Secret{Name: "b", Value: "2"}, Secret{Name: "c", Value: "3"})
+	dst := newCountingStore(Secret{Name: "b", Value: "old"})
+	r, err := Migrate(context.Background(), src, dst, MigrateOptions{Concurrency: 2, DryRun: true})
+	if err != nil || len(r.Copied) != 2 || dst.sets != 0 {
+		t.Fatal(r, err)
+	}
+	r, err = Migrate(context.Background(), src, dst, MigrateOptions{Concurrency: 2})
+	if err != nil || len(r.Copied) != 2 || len(r.Skipped) != 1 {
+		t.Fatal(r, err)
+	}
+	if a, _ := dst.Get("a"); a.Value != "1" {
+		t.Fatal(a)
+	}
+	if b, _ := dst.Get("b"); b.Value != "old" {
+		t.Fatal(b)
+	}
+	r, _ = Migrate(context.Background(), src, dst, MigrateOptions{Overwrite: true})
+	if b, _ := dst.Get("b"); len(r.Copied) != 3 || b.Value != "2" {
+		t.Fatal(r, b)
+	}
+}
+
+// deletedListing is a MemoryStore whose List also reports a soft-deleted
+// secret, like a vault client set up with WithIncludeDeleted.
+type deletedListing struct {
+	*MemoryStore
+}
+
+func (d deletedListing) List() ([]Secret, *errors.Error) {
+	secrets, err := d.MemoryStore.List()
+	return append(secrets, Secret{Name: "gone", Deleted: true}), err
+}
+
+func TestMigrateContextAndDeleted(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "migrate")
+	f := vaultFake()
+	set := f.setSecret
+	f.setSecret = func(c context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		if c.Value(key{}) != "migrate" {
+			t.Error("set outside the migration context")
+		}
+		return set(c, name, p)
+	}
+	dst := newTestManager(context.Background(), f)
+	src := deletedListing{NewMemoryStore(Secret{Name: "a", Value: "1"})}
+	r, err := Migrate(ctx, src, dst, MigrateOptions{})
+	if err != nil || len(r.Copied) != 1 || r.Copied[0] != "a" || len(r.Failed) != 0 {
+		t.Fatal(r, err)
+	}
+}
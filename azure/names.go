@@ -0,0 +1,42 @@
+package azure
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// maxSecretNameLen is the longest secret name KeyVault accepts.
+const maxSecretNameLen = 127
+
+var (
+	secretNamePattern = regexp.MustCompile(`^[0-9a-zA-Z-]+$`)
+	// Vault names start with a letter, end with a letter or digit and have
+	// no consecutive hyphens; the length is checked separately.
+	vaultNamePattern = regexp.MustCompile(`^[a-zA-Z](-?[0-9a-zA-Z])*$`)
+)
+
+// validateSecretName returns a Validation error when KeyVault would reject
+// name, so that the request is not sent.
+func validateSecretName(name string) *errors.Error {
+	switch {
+	case name == "":
+		return errors.ValidationError("secret name must not be empty")
+	case len(name) > maxSecretNameLen:
+		return errors.ValidationError(fmt.Sprintf("secret name %q is longer than %d characters", name, maxSecretNameLen))
+	case !secretNamePattern.MatchString(name):
+		return errors.ValidationError(fmt.Sprintf("secret name %q may only contain letters, digits and hyphens", name))
+	}
+	return nil
+}
+
+// validateVaultName returns a Validation error when name is not a valid
+// vault name: 3 to 24 letters, digits and hyphens, starting with a letter,
+// ending with a letter or digit and without consecutive hyphens.
+func validateVaultName(name string) *errors.Error {
+	if len(name) < 3 || len(name) > 24 || !vaultNamePattern.MatchString(name) {
+		return errors.ValidationError(fmt.Sprintf("invalid vault name %q: must be 3 to 24 letters, digits and single hyphens, starting with a letter and not ending with a hyphen", name))
+	}
+	return nil
+}
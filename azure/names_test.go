@@ -0,0 +1,37 @@
+package azure
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestNames(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ok   bool
+	}{{"", false}, {"a", true}, {"db-password-2", true}, {strings.Repeat("a", 127), true}, {strings.Repeat("a", 128), false}, {"a_b", false}, {"a.b", false}, {"a/b", false}} {
+		if err := validateSecretName(tc.name); (err == nil) != tc.ok || (err != nil && err.Code != errors.ErrCodeValidation) {
+			t.Fatal(tc.name, err)
+		}
+	}
+	for _, tc := range []struct {
+		name string
+		ok   bool
+	}{{"", false}, {"ab", false}, {"abc", true}, {"my-vault-1", true}, {"1vault", false}, {"vault-", false}, {"my--vault", false}, {strings.Repeat("a", 25), false}, {"my_vault", false}} {
+		if err := validateVaultName(tc.name); (err == nil) != tc.ok {
+			t.Fatal(tc.name, err)
+		}
+	}
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		t.Fatal("request sent")
+		return azsecrets.GetSecretResponse{}, nil
+	}}
+	if _, err := newTestManager(context.Background(), f).Get("a_b"); err == nil || err.Code != errors.ErrCodeValidation {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,39 @@
+package azure
+
+// Operation names reported to observers.
+const (
+	opListSecrets            = "ListSecrets"
+	opListSecretVersions     = "ListSecretVersions"
+	opListDeletedSecrets     = "ListDeletedSecrets"
+	opGetDeletedSecret       = "GetDeletedSecret"
+	opGetSecret              = "GetSecret"
+	opSetSecret              = "SetSecret"
+	opDeleteSecret           = "DeleteSecret"
+	opPurgeDeletedSecret     = "PurgeDeletedSecret"
+	opRecoverDeletedSecret   = "RecoverDeletedSecret"
+	opBackupSecret           = "BackupSecret"
+	opRestoreSecret          = "RestoreSecret"
+	opUpdateSecretProperties = "UpdateSecretProperties"
+)
+
+// Observer is notified of conditions that are worth surfacing but do not fail
+// the operation, such as malformed entries skipped by List.
+type Observer interface {
+	// Warning is called with the operation, the secret name it concerns (empty
+	// when unknown) and a description of the condition. It never receives
+	// secret values.
+	Warning(operation, name, message string)
+}
+
+// operationName returns the name under which the client reports an
+// operation, with the prefix set by WithOperationNamePrefix.
+func (kvc *KeyVaultClient) operationName(operation string) string {
+	return kvc.operationPrefix + operation
+}
+
+// warn forwards a warning to the configured observer, if any.
+func (kvc *KeyVaultClient) warn(operation, name, message string) {
+	if kvc.observer != nil {
+		kvc.observer.Warning(kvc.operationName(operation), name, message)
+	}
+}
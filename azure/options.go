@@ -0,0 +1,275 @@
+package azure
+
+import (
+	"maps"
+	"strings"
+	"time"
+)
+
+// KeyVaultClientOption configures a KeyVaultClient.
+type KeyVaultClientOption func(*KeyVaultClient)
+
+// WithNameAllowlist restricts the client to the secrets whose name matches at
+// least one of the given patterns. Patterns use the path.Match syntax, e.g.
+// "app-*". An empty list allows every name.
+func WithNameAllowlist(patterns []string) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.nameAllowlist = patterns
+	}
+}
+
+// WithNameDenylist forbids the secrets whose name matches any of the given
+// patterns, using the same syntax as WithNameAllowlist. The denylist takes
+// precedence over the allowlist.
+func WithNameDenylist(patterns []string) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.nameDenylist = patterns
+	}
+}
+
+// WithNameMapper lets callers address secrets by keys that are not valid
+// KeyVault names, such as "db.password". toVault maps a caller key to the
+// vault name used by Get, Set, Delete and the other single-secret operations;
+// fromVault maps the names returned by List back to caller keys and may be
+// nil to return vault names.
+//
+// For List to return the keys callers use, fromVault must be the inverse of
+// toVault and toVault must be bijective over the keys in use: DotsToDashes,
+// for instance, only is when the keys contain no dashes.
+func WithNameMapper(toVault, fromVault func(string) string) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.toVaultName = toVault
+		kvc.fromVaultName = fromVault
+	}
+}
+
+// DotsToDashes maps "db.password" to "db-password". It is meant as the
+// toVault function of WithNameMapper, with DashesToDots as its inverse.
+func DotsToDashes(name string) string {
+	return strings.ReplaceAll(name, ".", "-")
+}
+
+// DashesToDots maps "db-password" to "db.password".
+func DashesToDots(name string) string {
+	return strings.ReplaceAll(name, "-", ".")
+}
+
+// WithObserver registers an Observer notified of non-fatal conditions.
+func WithObserver(observer Observer) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.observer = observer
+	}
+}
+
+// WithTraceIDRedaction controls whether the KeyVault request id is left out of
+// the TraceId of returned errors, for environments that treat request ids as
+// sensitive. Request ids are included by default.
+func WithTraceIDRedaction(redact bool) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.redactTraceID = redact
+	}
+}
+
+// MaxAgeMode selects what Get does with a secret older than the maximum age.
+type MaxAgeMode int
+
+const (
+	// MaxAgeWarn returns the secret and reports it to the observer.
+	MaxAgeWarn MaxAgeMode = iota
+	// MaxAgeError refuses the secret with a validation error.
+	MaxAgeError
+)
+
+// WithMaxAge enforces a maximum age on the secrets returned by Get and the
+// other getters of values, e.g. GetBytes or GetSecure, measured from the
+// creation of their current version. Zero disables the check.
+func WithMaxAge(maxAge time.Duration, mode MaxAgeMode) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.maxAge = maxAge
+		kvc.maxAgeMode = mode
+	}
+}
+
+// WithRetryPolicy retries the single-secret operations that fail with a
+// transient error according to policy. Retries are disabled by default,
+// leaving only those of the Azure SDK pipeline.
+func WithRetryPolicy(policy RetryPolicy) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.retryPolicy = policy
+	}
+}
+
+// WithRetryHook registers a hook called before every retry made by the retry
+// policy, e.g. to log or alert on excessive retrying.
+func WithRetryHook(hook RetryHook) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.retryHook = hook
+	}
+}
+
+// WithRetryPredicate makes the retry policy retry the failures predicate
+// accepts instead of the transient ones, e.g. to retry NotFound errors while a
+// new secret propagates. MaxRetries and the backoff still apply.
+func WithRetryPredicate(predicate RetryPredicate) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.retryPredicate = predicate
+	}
+}
+
+// WithMaxRetryDelay caps the delay between two retries of the retry policy,
+// which otherwise doubles without bound. Zero or less leaves it uncapped.
+func WithMaxRetryDelay(d time.Duration) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.maxRetryDelay = d
+	}
+}
+
+// WithResolver sets the resolver ResolvedEndpoint looks the vault host up
+// with. It defaults to net.DefaultResolver.
+func WithResolver(resolver Resolver) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.resolver = resolver
+	}
+}
+
+// WithRedactionStyle sets how secret values are shown where they are
+// redacted. It defaults to RedactMask.
+func WithRedactionStyle(style RedactionStyle) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.redactionStyle = style
+	}
+}
+
+// WithValueCompression makes Set store values gzipped, for large values that
+// would not fit the size limit of KeyVault otherwise. A value is only stored
+// compressed when that makes it shorter, and is marked with a tag so that Get
+// decompresses it whether or not the reading client enables compression.
+func WithValueCompression(compress bool) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.compressValues = compress
+	}
+}
+
+// WithVersionChangeHook registers a hook called when consecutive reads of the
+// latest value of a name, by Get or any other getter, return different
+// versions, so that applications can react to rotations without watching
+// the vault.
+func WithVersionChangeHook(hook VersionChangeHook) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.versions = newVersionTracker(hook)
+	}
+}
+
+// WithValueValidator makes Set call validate with the name and value of every
+// secret before writing it, e.g. to enforce password complexity or a JSON
+// schema. A non-nil error aborts the write with a Validation error, in whose
+// message the value is redacted.
+func WithValueValidator(validate func(name, value string) error) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.valueValidator = validate
+	}
+}
+
+// WithAllowExpired lets Set write secrets whose expiration is already in the
+// past, e.g. to Import an export faithfully. By default they are rejected
+// with a Validation error, as they could never be read.
+func WithAllowExpired(allow bool) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.allowExpired = allow
+	}
+}
+
+// WithVaultURL makes the client address the vault at vaultURL verbatim, e.g.
+// a private endpoint, a proxy or a local emulator, instead of deriving the URL
+// from the vault name. The URL must use https unless
+// WithInsecureVaultURL is set.
+func WithVaultURL(vaultURL string) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.vaultURL = vaultURL
+	}
+}
+
+// WithInsecureVaultURL allows an http URL in WithVaultURL and sends the
+// credentials over it. It is meant for mock servers in local testing only.
+func WithInsecureVaultURL(insecure bool) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.insecureVaultURL = insecure
+	}
+}
+
+// WithEagerAuth makes NewKeyVaultClient acquire a token right away and fail
+// with an Unauthorized error when it cannot, so that deployments surface
+// authentication problems at boot. By default authentication is deferred to
+// the first operation.
+func WithEagerAuth(eager bool) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.eagerAuth = eager
+	}
+}
+
+// WithOperationNamePrefix prefixes the operation names the client reports
+// (e.g. "GetSecret" becomes "payments.GetSecret" with prefix "payments."), to
+// tell its telemetry apart from that of other vaults or SDKs.
+func WithOperationNamePrefix(prefix string) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.operationPrefix = prefix
+	}
+}
+
+// InvalidUTF8Mode selects what Get does with a value that is not valid UTF-8,
+// typically binary data stored without an encoding. Such a value cannot go
+// through encoding/json without being altered.
+type InvalidUTF8Mode int
+
+const (
+	// InvalidUTF8Warn returns the value and reports it to the observer.
+	InvalidUTF8Warn InvalidUTF8Mode = iota
+	// InvalidUTF8Reject refuses the value with a validation error. GetSecure
+	// and GetToWriter still return it as bytes.
+	InvalidUTF8Reject
+)
+
+// WithInvalidUTF8Mode sets how Get handles values that are not valid UTF-8.
+// The default is InvalidUTF8Warn.
+func WithInvalidUTF8Mode(mode InvalidUTF8Mode) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.invalidUTF8Mode = mode
+	}
+}
+
+// WithExistenceCache makes Exists remember for ttl whether a secret exists.
+// Only presence is cached, never values; Set and Delete through the client
+// invalidate the entry of the secret they change.
+func WithExistenceCache(ttl time.Duration) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.existence = newExistenceCache(ttl)
+	}
+}
+
+// WithDefaultTags merges tags into the tags of every secret written by Set,
+// e.g. to enforce an owner or cost-center tag. Tags given by the caller take
+// precedence over the defaults.
+func WithDefaultTags(tags map[string]string) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.defaultTags = maps.Clone(tags)
+	}
+}
+
+// WithDisabledSetWarning makes Set check, at the cost of one extra request,
+// whether the current version of the secret is disabled, and warn the
+// observer if so: Set creates a new, enabled version, which re-enables a
+// secret that may have been disabled on purpose.
+func WithDisabledSetWarning(enabled bool) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.warnDisabledSet = enabled
+	}
+}
+
+// WithIncludeDeleted makes List also return the soft-deleted secrets of the
+// vault, with Secret.Deleted set. List returns active secrets only by
+// default.
+func WithIncludeDeleted(include bool) KeyVaultClientOption {
+	return func(kvc *KeyVaultClient) {
+		kvc.includeDeleted = include
+	}
+}
@@ -0,0 +1,99 @@
+package azure
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// checkMaxAge applies the maximum age configured with WithMaxAge to a secret
+// read by any getter of values. The age is measured from the creation of the current version,
+// falling back to its last update.
+func (kvc *KeyVaultClient) checkMaxAge(name string, attrs *azsecrets.SecretAttributes) *errors.Error {
+	if kvc.maxAge <= 0 || attrs == nil {
+		return nil
+	}
+
+	since := attrs.Created
+	if since == nil {
+		since = attrs.Updated
+	}
+	if since == nil {
+		return nil
+	}
+
+	age := kvc.now().Sub(*since)
+	if age <= kvc.maxAge {
+		return nil
+	}
+
+	message := fmt.Sprintf("secret %q is %s old, exceeding the maximum age of %s", name, age.Round(time.Second), kvc.maxAge)
+	if kvc.maxAgeMode == MaxAgeError {
+		return errors.ValidationError(message)
+	}
+	kvc.warn(opGetSecret, name, message)
+
+	return nil
+}
+
+// validateValidity rejects a secret whose activation date is after its
+// expiration, which KeyVault accepts but which is never valid.
+func validateValidity(secret Secret) *errors.Error {
+	if secret.NotBefore.IsZero() || secret.Expiration.IsZero() {
+		return nil
+	}
+	if secret.NotBefore.After(secret.Expiration) {
+		return errors.ValidationError(fmt.Sprintf("secret %q has a NotBefore (%s) after its Expiration (%s)",
+			secret.Name, secret.NotBefore.Format(time.RFC3339), secret.Expiration.Format(time.RFC3339)))
+	}
+	return nil
+}
+
+// checkExpired rejects a secret about to be written by Set with an
+// expiration already in the past, unless WithAllowExpired is set.
+func (kvc *KeyVaultClient) checkExpired(secret Secret) *errors.Error {
+	if kvc.allowExpired || secret.Expiration.IsZero() {
+		return nil
+	}
+	if now := kvc.now(); secret.Expiration.Before(now) {
+		return errors.ValidationError(fmt.Sprintf("secret %q has an Expiration (%s) in the past, %s ago",
+			secret.Name, secret.Expiration.Format(time.RFC3339), now.Sub(secret.Expiration).Round(time.Second)))
+	}
+	return nil
+}
+
+// checkUTF8 applies the mode set with WithInvalidUTF8Mode to a value read by
+// Get.
+func (kvc *KeyVaultClient) checkUTF8(name, value string) *errors.Error {
+	if utf8.ValidString(value) {
+		return nil
+	}
+
+	if kvc.invalidUTF8Mode == InvalidUTF8Reject {
+		return errors.ValidationError(fmt.Sprintf("secret %q is not valid UTF-8, read it with GetSecure or GetToWriter", name))
+	}
+	if kvc.redactionStyle == RedactOmit {
+		kvc.warn(opGetSecret, name, "value is not valid UTF-8")
+	} else {
+		kvc.warn(opGetSecret, name, fmt.Sprintf("value %s is not valid UTF-8", redactValue(kvc.redactionStyle, len(value))))
+	}
+
+	return nil
+}
+
+// validateValue applies the validator set with WithValueValidator to a value
+// about to be written by Set.
+func (kvc *KeyVaultClient) validateValue(secret Secret) *errors.Error {
+	if kvc.valueValidator == nil {
+		return nil
+	}
+	if err := kvc.valueValidator(secret.Name, secret.Value); err != nil {
+		message := scrubValue(kvc.redactionStyle, err.Error(), secret.Value)
+		return errors.ValidationError(fmt.Sprintf("secret %q failed validation: %s", secret.Name, message))
+	}
+	return nil
+}
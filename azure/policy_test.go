@@ -0,0 +1,144 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestMaxAge(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("v"), Attributes: &azsecrets.SecretAttributes{Created: &created}}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	now := created.Add(24 * time.Hour)
+	ksm.kvClient.clock = func() time.Time { return now }
+	ksm.kvClient.maxAge = 24 * time.Hour
+	ksm.kvClient.maxAgeMode = MaxAgeError
+	if _, err := ksm.Get("a"); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Second)
+	if _, err := ksm.Get("a"); err == nil || err.Code != "ValidationError" {
+		t.Fatal(err)
+	}
+	o := &recObs{}
+	ksm.kvClient.observer = o
+	ksm.kvClient.maxAgeMode = MaxAgeWarn
+	if _, err := ksm.Get("a"); err != nil || len(o.msgs) != 1 {
+		t.Fatal(err, o.msgs)
+	}
+	t.Log(o.msgs)
+}
+
+func TestValidity(t *testing.T) {
+	var got *azsecrets.SetSecretParameters
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		got = &p
+		return azsecrets.SetSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	now := time.Now()
+	if err := ksm.Set(Secret{Name: "a", NotBefore: now.Add(time.Hour), Expiration: now}); err == nil || got != nil {
+		t.Fatal(err)
+	}
+	if err := ksm.Set(Secret{Name: "a", NotBefore: now, Expiration: now.Add(time.Hour)}); err != nil || got.SecretAttributes.NotBefore == nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUTF8(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("a\xff\xfe")}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	o := &recObs{}
+	ksm.kvClient.observer = o
+	if s, err := ksm.Get("a"); err != nil || s.Value != "a\xff\xfe" || len(o.msgs) != 1 {
+		t.Fatal(err)
+	}
+	ksm.kvClient.invalidUTF8Mode = InvalidUTF8Reject
+	if _, err := ksm.Get("a"); err == nil || err.Code != errors.ErrCodeValidation {
+		t.Fatal(err)
+	}
+	if s, err := ksm.GetSecure("a"); err != nil || len(s.Bytes()) != 3 {
+		t.Fatal(err)
+	}
+}
+
+func TestValueValidator(t *testing.T) {
+	sets := 0
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		sets++
+		return azsecrets.SetSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.kvClient.valueValidator = func(name, value string) error {
+		if len(value) < 8 {
+			return fmt.Errorf("too short")
+		}
+		return nil
+	}
+	if err := ksm.Set(Secret{Name: "a", Value: "s3cr3t"}); err == nil || err.Code != errors.ErrCodeValidation || !strings.Contains(err.Message, "too short") || sets != 0 {
+		t.Fatal(err)
+	}
+	if err := ksm.Set(Secret{Name: "a", Value: "long enough"}); err != nil || sets != 1 {
+		t.Fatal(err)
+	}
+}
+
+func TestRejectExpired(t *testing.T) {
+	sets := 0
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		sets++
+		return azsecrets.SetSecretResponse{}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	now := time.Unix(1e9, 0)
+	ksm.kvClient.clock = func() time.Time { return now }
+	if err := ksm.Set(Secret{Name: "a", Value: "1", Expiration: now.Add(-time.Minute)}); err == nil || err.Code != errors.ErrCodeValidation || sets != 0 {
+		t.Fatal(err)
+	}
+	if err := ksm.Set(Secret{Name: "a", Value: "1", Expiration: now.Add(time.Minute)}); err != nil || sets != 1 {
+		t.Fatal(err)
+	}
+	if err := ksm.Set(Secret{Name: "a", Value: "1"}); err != nil || sets != 2 {
+		t.Fatal(err)
+	}
+	if err := ksm.SetValue("a", "1", time.Second); err != nil || sets != 3 {
+		t.Fatal(err)
+	}
+	ksm.kvClient.allowExpired = true
+	if err := ksm.Set(Secret{Name: "a", Value: "1", Expiration: now.Add(-time.Minute)}); err != nil || sets != 4 {
+		t.Fatal(err)
+	}
+}
+
+func TestMaxAgeAllGetters(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("v"), Attributes: &azsecrets.SecretAttributes{Created: &created}}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.kvClient.clock = func() time.Time { return created.Add(48 * time.Hour) }
+	ksm.kvClient.maxAge = 24 * time.Hour
+	ksm.kvClient.maxAgeMode = MaxAgeError
+	if _, err := ksm.GetBytes("a"); err == nil || err.Code != "ValidationError" {
+		t.Fatal("GetBytes", err)
+	}
+	if _, err := ksm.GetSecure("a"); err == nil || err.Code != "ValidationError" {
+		t.Fatal("GetSecure", err)
+	}
+	var buf bytes.Buffer
+	if err := ksm.GetToWriter("a", &buf); err == nil || err.Code != "ValidationError" || buf.Len() != 0 {
+		t.Fatal("GetToWriter", err)
+	}
+}
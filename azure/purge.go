@@ -0,0 +1,60 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// purgeProtectionMessage is part of the message KeyVault returns when a purge
+// is refused because of purge protection.
+const purgeProtectionMessage = "purge protection"
+
+// PurgeDeletedSecret permanently deletes the soft-deleted secret with the
+// given name, before its scheduled purge date. When purge protection is
+// enabled on the vault the secret cannot be purged until its retention period
+// elapses, and a PurgeProtected error says so, with the date KeyVault purges
+// it on its own when it is known.
+func (ksm *KeyVaultSecretsManager) PurgeDeletedSecret(name string) *errors.Error {
+	vaultName, err := ksm.kvClient.vaultSecretName(name)
+	if err != nil {
+		return err
+	}
+
+	start := ksm.kvClient.now()
+	err = ksm.kvClient.retry(ksm.kvClient.ctx, func() *errors.Error {
+		if _, err := ksm.secretsClient.PurgeDeletedSecret(ksm.kvClient.ctx, vaultName, nil); err != nil {
+			return ksm.kvClient.azError(err)
+		}
+		return nil
+	})
+	if err != nil && isPurgeProtected(err) {
+		err = ksm.purgeProtectedError(vaultName, err)
+	}
+
+	return ksm.kvClient.operationError(ksm.kvClient.ctx, opPurgeDeletedSecret, name, start, err)
+}
+
+// isPurgeProtected reports whether err is KeyVault refusing a purge because of
+// purge protection. KeyVault has no dedicated code for it, so the message is
+// all there is to tell it from a missing purge permission.
+func isPurgeProtected(err *errors.Error) bool {
+	return err.Code == errors.ErrCodeInsufficientAccess &&
+		strings.Contains(strings.ToLower(err.Message), purgeProtectionMessage)
+}
+
+// purgeProtectedError turns the refusal err into a PurgeProtected error
+// wrapping it, looking up when the deleted secret becomes eligible for
+// purging.
+func (ksm *KeyVaultSecretsManager) purgeProtectedError(vaultName string, err *errors.Error) *errors.Error {
+	message := "purge protection is enabled on the vault, the secret is purged once its retention period elapses"
+	if resp, getErr := ksm.secretsClient.GetDeletedSecret(ksm.kvClient.ctx, vaultName, nil); getErr == nil && resp.ScheduledPurgeDate != nil {
+		message += fmt.Sprintf(", on %s", resp.ScheduledPurgeDate.UTC().Format(time.RFC3339))
+	}
+
+	e := errors.PurgeProtectedError(message).WithCause(err)
+	e.TraceId = err.TraceId
+	return e
+}
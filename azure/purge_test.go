@@ -0,0 +1,51 @@
+package azure
+
+import (
+	"context"
+	stdErrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestPurgeProtected(t *testing.T) {
+	d := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeOps{purge: func(string) (azsecrets.PurgeDeletedSecretResponse, error) {
+		return azsecrets.PurgeDeletedSecretResponse{}, respErr(403, `{"error":{"code":"Forbidden","message":"Operation \"purge\" is not allowed because purge protection is enabled for this vault."}}`, map[string]string{"x-ms-request-id": "r1"})
+	}, getDeleted: func(string) (azsecrets.GetDeletedSecretResponse, error) {
+		return azsecrets.GetDeletedSecretResponse{DeletedSecret: azsecrets.DeletedSecret{ScheduledPurgeDate: &d}}, nil
+	}}
+	err := newTestManager(context.Background(), f).PurgeDeletedSecret("a")
+	if err == nil || err.Code != errors.ErrCodePurgeProtected || !strings.Contains(err.Message, "2026-11-01T00:00:00Z") || err.TraceId != "r1" {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(err.Message, `PurgeDeletedSecret "a": `) || !stdErrors.Is(err, ErrForbidden) {
+		t.Fatal(err)
+	}
+	var azErr *azcore.ResponseError
+	if !stdErrors.As(err, &azErr) || azErr.StatusCode != 403 {
+		t.Fatal(err)
+	}
+	f.purge = func(string) (azsecrets.PurgeDeletedSecretResponse, error) {
+		return azsecrets.PurgeDeletedSecretResponse{}, respErr(403, `{"error":{"code":"Forbidden","message":"no purge permission"}}`, nil)
+	}
+	if err := newTestManager(context.Background(), f).PurgeDeletedSecret("a"); err == nil || err.Code != errors.ErrCodeInsufficientAccess {
+		t.Fatal(err)
+	}
+}
+
+func TestPurgeOK(t *testing.T) {
+	var got string
+	f := &fakeOps{purge: func(name string) (azsecrets.PurgeDeletedSecretResponse, error) {
+		got = name
+		return azsecrets.PurgeDeletedSecretResponse{}, nil
+	}}
+	if err := newTestManager(context.Background(), f).PurgeDeletedSecret("a"); err != nil || got != "a" {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,74 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// rateLimiter is a token bucket holding a second worth of requests, refilled
+// at a fixed rate. As a pipeline policy it delays every request sent to the
+// vault, retries included, until a token is available.
+type rateLimiter struct {
+	interval time.Duration
+	burst    time.Duration
+
+	mu sync.Mutex
+	// next is when the next request is due once the bucket is empty. A
+	// full bucket lets it lag up to burst behind now.
+	next time.Time
+}
+
+func newRateLimiter(rps int) *rateLimiter {
+	interval := time.Second / time.Duration(rps)
+	return &rateLimiter{interval: interval, burst: time.Duration(rps-1) * interval}
+}
+
+// wait blocks until a token is available or ctx is done. A wait cut short by
+// ctx gives its token back, unless later waits have reserved tokens after
+// it, as the Cancel of a golang.org/x/time/rate Reservation does.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if earliest := now.Add(-l.burst); at.Before(earliest) {
+		at = earliest
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := at.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel(at)
+		return ctx.Err()
+	}
+}
+
+// cancel gives back the token reserved for at by a wait that gave up.
+func (l *rateLimiter) cancel(at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.next.Equal(at.Add(l.interval)) {
+		l.next = at
+	}
+}
+
+// Do implements policy.Policy.
+func (l *rateLimiter) Do(req *policy.Request) (*http.Response, error) {
+	if err := l.wait(req.Raw().Context()); err != nil {
+		return nil, err
+	}
+	return req.Next()
+}
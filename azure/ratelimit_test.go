@@ -0,0 +1,59 @@
+package azure
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestRateLimit(t *testing.T) {
+	var n int
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		n++
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"value":"x","id":"https://vlt.vault.azure.net/secrets/db/v1"}`)), Request: r}, nil
+	})}
+	c, _ := newClient(&fakeProvider{cred: &fakeCred{}}, WithHTTPClient(hc), WithRateLimit(50))
+	kvc, _ := NewKeyVaultClient(context.Background(), c, "vlt")
+	ksm := NewKeyVaultSecretsManager(kvc)
+	start := time.Now()
+	for range 60 {
+		if _, err := ksm.Get("db"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond || n != 60 {
+		t.Fatal(elapsed, n)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	c, _ = newClient(&fakeProvider{cred: &fakeCred{}}, WithHTTPClient(hc), WithRateLimit(1))
+	kvc, _ = NewKeyVaultClient(ctx, c, "vlt")
+	ksm = NewKeyVaultSecretsManager(kvc)
+	ksm.Get("db")
+	if _, err := ksm.Get("db"); err == nil || err.Code != errors.ErrCodeTimeout {
+		t.Fatal(err)
+	}
+}
+
+func TestRateLimitCancel(t *testing.T) {
+	l := newRateLimiter(1)
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	next := l.next
+	for range 5 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		if err := l.wait(ctx); err == nil {
+			t.Fatal("expected the wait to be cut short")
+		}
+		cancel()
+	}
+	if !l.next.Equal(next) {
+		t.Fatal("cancelled waits kept their tokens:", l.next.Sub(next))
+	}
+}
@@ -0,0 +1,72 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RedactionStyle controls how secret values are shown where they are
+// redacted: by Secret.String, Secret.Redacted, SecureSecret.String and in the
+// warnings sent to the observer.
+type RedactionStyle int
+
+const (
+	// RedactMask shows every value as the same fixed mask. This is the
+	// default.
+	RedactMask RedactionStyle = iota
+	// RedactLength shows the length of the value, which helps telling an
+	// empty or truncated value apart but leaks a little about it.
+	RedactLength
+	// RedactOmit leaves the value out altogether.
+	RedactOmit
+)
+
+// redactedMask replaces a value redacted with RedactMask.
+const redactedMask = "<redacted>"
+
+// redactValue returns a value of the given length as shown in the given
+// style. It takes the length only, so that callers holding the value in a
+// byte slice do not have to copy it into a string.
+func redactValue(style RedactionStyle, length int) string {
+	switch style {
+	case RedactLength:
+		return fmt.Sprintf("<redacted %d bytes>", length)
+	case RedactOmit:
+		return ""
+	default:
+		return redactedMask
+	}
+}
+
+// String implements fmt.Stringer without revealing the value, which is shown
+// in the redaction style of the client that returned the secret.
+func (s Secret) String() string {
+	if s.redaction == RedactOmit {
+		return fmt.Sprintf("Secret{Name:%s}", s.Name)
+	}
+	return fmt.Sprintf("Secret{Name:%s Value:%s}", s.Name, redactValue(s.redaction, len(s.Value)))
+}
+
+// GoString implements fmt.GoStringer so that %#v does not reveal the value
+// either.
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+// Redacted returns a copy of the secret whose value is replaced as String
+// shows it, e.g. to log or serialize it.
+func (s Secret) Redacted() Secret {
+	r := s.clone()
+	r.Value = redactValue(s.redaction, len(s.Value))
+	return r
+}
+
+// scrubValue replaces every occurrence of value in message as redactValue
+// shows it, for error messages built from text that may quote the value,
+// such as that of a value validator.
+func scrubValue(style RedactionStyle, message, value string) string {
+	if value == "" {
+		return message
+	}
+	return strings.ReplaceAll(message, value, redactValue(style, len(value)))
+}
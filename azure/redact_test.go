@@ -0,0 +1,28 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestRedactionStyle(t *testing.T) {
+	for style, want := range map[RedactionStyle]string{RedactMask: "Secret{Name:a Value:<redacted>}", RedactLength: "Secret{Name:a Value:<redacted 6 bytes>}", RedactOmit: "Secret{Name:a}"} {
+		f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+			v := "s3cr\xff!"
+			return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: &v}}, nil
+		}}
+		ksm := newTestManager(context.Background(), f)
+		obs := &recObs{}
+		ksm.kvClient.observer = obs
+		ksm.kvClient.redactionStyle = style
+		s, _ := ksm.Get("a")
+		if s.String() != want || fmt.Sprint(*s) != want || strings.Contains(s.Redacted().Value, "s3cr") {
+			t.Fatal(style, s.String())
+		}
+		t.Log(obs)
+	}
+}
@@ -0,0 +1,81 @@
+package azure
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// RetryPolicy controls how this package retries operations that fail with a
+// transient error, on top of the retries of the Azure SDK pipeline.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry. It doubles on every
+	// following retry.
+	BaseDelay time.Duration
+}
+
+// RetryHook is called before a retry with the number of the retry (starting
+// at 1), the error that triggered it and the delay before it is made.
+type RetryHook func(attempt int, err *errors.Error, nextDelay time.Duration)
+
+// RetryPredicate decides whether to retry after the given attempt (starting
+// at 1) failed with err. It replaces the default, which retries transient
+// errors only.
+type RetryPredicate func(attempt int, err *errors.Error) bool
+
+// retry runs op, retrying it according to the retry policy of the client
+// while it fails with an error worth retrying. Waiting between attempts stops
+// as soon as ctx is done.
+func (kvc *KeyVaultClient) retry(ctx context.Context, op func() *errors.Error) *errors.Error {
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || attempt > kvc.retryPolicy.MaxRetries || !kvc.shouldRetry(attempt, err) {
+			return err
+		}
+
+		delay := kvc.retryDelay(attempt, err.RetryAfter)
+		if kvc.retryHook != nil {
+			kvc.retryHook(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return kvc.azError(ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry applies the retry predicate of the client, or the default
+// transient classification when there is none.
+func (kvc *KeyVaultClient) shouldRetry(attempt int, err *errors.Error) bool {
+	if kvc.retryPredicate != nil {
+		return kvc.retryPredicate(attempt, err)
+	}
+	return err.IsTransient()
+}
+
+// retryDelay returns the delay before the given retry: the base delay doubled
+// on every retry, or the delay KeyVault asked for when longer, capped by
+// WithMaxRetryDelay.
+func (kvc *KeyVaultClient) retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	delay := kvc.retryPolicy.BaseDelay
+	for range attempt - 1 {
+		if delay > math.MaxInt64/2 {
+			delay = math.MaxInt64
+			break
+		}
+		delay *= 2
+	}
+	delay = max(delay, retryAfter)
+	if kvc.maxRetryDelay > 0 {
+		delay = min(delay, kvc.maxRetryDelay)
+	}
+	return delay
+}
@@ -0,0 +1,81 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestRetryHook(t *testing.T) {
+	n := 0
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		n++
+		if n < 4 {
+			return azsecrets.GetSecretResponse{}, respErr(429, "", nil)
+		}
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("v")}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.kvClient.retryPolicy = RetryPolicy{MaxRetries: 5, BaseDelay: time.Millisecond}
+	var attempts []int
+	var delays []time.Duration
+	ksm.kvClient.retryHook = func(a int, err *errors.Error, d time.Duration) {
+		attempts = append(attempts, a)
+		delays = append(delays, d)
+	}
+	if _, err := ksm.Get("a"); err != nil || len(attempts) != 3 || attempts[2] != 3 || delays[2] != 4*time.Millisecond {
+		t.Fatal(err, attempts, delays)
+	}
+}
+
+func TestMaxRetryDelay(t *testing.T) {
+	kvc := &KeyVaultClient{retryPolicy: RetryPolicy{MaxRetries: 100, BaseDelay: time.Second}, maxRetryDelay: 5 * time.Second}
+	for a := 1; a <= 100; a++ {
+		if d := kvc.retryDelay(a, 0); d > 5*time.Second || d <= 0 {
+			t.Fatal(a, d)
+		}
+	}
+	kvc.maxRetryDelay = 0
+	if d := kvc.retryDelay(80, 0); d <= 0 {
+		t.Fatal(d)
+	}
+}
+
+func TestRetryPredicate(t *testing.T) {
+	n := 0
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		n++
+		if n < 3 {
+			return azsecrets.GetSecretResponse{}, respErr(404, "", nil)
+		}
+		v := "x"
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: &v}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.kvClient.retryPolicy = RetryPolicy{MaxRetries: 3}
+	ksm.kvClient.retryPredicate = func(_ int, err *errors.Error) bool { return err.Code == errors.ErrCodeNotFound }
+	s, err := ksm.Get("a")
+	if err != nil || s.Value != "x" || n != 3 {
+		t.Fatal(err, n)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	e := checkAzErrResp(respErr(429, `{"error":{"message":"slow down"}}`, map[string]string{"Retry-After": "30"}))
+	if e.Code != errors.ErrCodeThrottled || e.RetryAfter != 30*time.Second || !e.IsTransient() {
+		t.Fatal(e)
+	}
+	e = checkAzErrResp(respErr(503, "", map[string]string{"Retry-After": time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}))
+	if e.RetryAfter < 58*time.Second || e.RetryAfter > time.Minute {
+		t.Fatal(e.RetryAfter)
+	}
+	kvc := &KeyVaultClient{retryPolicy: RetryPolicy{BaseDelay: time.Millisecond}, maxRetryDelay: 5 * time.Second}
+	if d := kvc.retryDelay(1, 30*time.Second); d != 5*time.Second {
+		t.Fatal(d)
+	}
+}
@@ -0,0 +1,71 @@
+package azure
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// RotateOptions controls Rotate.
+type RotateOptions struct {
+	// DisablePrevious disables the version that was current before the
+	// rotation, so that the old value stops being readable.
+	DisablePrevious bool
+	// Expiration is the expiration of the new version. The zero time means
+	// it never expires.
+	Expiration time.Time
+}
+
+// Rotate sets newValue as the current version of the secret with the given
+// name, creating the secret when it does not exist, and returns the new
+// version. The new version keeps the content type and tags of the previous
+// one. KeyVault switches to it in one step, so readers see either value but
+// never none. When the previous version cannot be disabled afterwards, the
+// new version is returned along with the error.
+func (ksm *KeyVaultSecretsManager) Rotate(name, newValue string, opts RotateOptions) (*Secret, *errors.Error) {
+	ctx, end := ksm.kvClient.startOperation(ksm.kvClient.ctx, spanRotate, name)
+
+	secret, err := ksm.rotate(ctx, name, newValue, opts)
+	end(err)
+	return secret, err
+}
+
+func (ksm *KeyVaultSecretsManager) rotate(ctx context.Context, name, newValue string, opts RotateOptions) (*Secret, *errors.Error) {
+	secret := Secret{Name: name, Value: newValue, Expiration: opts.Expiration, redaction: ksm.kvClient.redactionStyle}
+
+	previous := ""
+	resp, err := ksm.getSecret(ctx, name, "")
+	switch {
+	case err == nil:
+		if resp.ID != nil {
+			previous = resp.ID.Version()
+		}
+		secret.ContentType = derefString(resp.ContentType)
+		secret.Tags = fromAzTags(resp.Tags)
+	case err.Code == errors.ErrCodeNotFound:
+		// First version of the secret.
+	case err.Code == errors.ErrCodeSecretDisabled:
+		// The previous version is disabled already, and its properties
+		// cannot be read.
+	default:
+		return nil, err
+	}
+
+	version, err := ksm.set(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	secret.Version = version
+
+	if opts.DisablePrevious && previous != "" && previous != version {
+		err := ksm.updateProperties(ctx, Secret{Name: name, Version: previous, Enabled: to.Ptr(false)})
+		if err != nil {
+			return &secret, err
+		}
+	}
+
+	return &secret, nil
+}
@@ -0,0 +1,47 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestRotate(t *testing.T) {
+	var set azsecrets.SetSecretParameters
+	exists := true
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		if !exists {
+			return azsecrets.GetSecretResponse{}, respErr(404, "", nil)
+		}
+		id := azsecrets.ID("https://vlt.vault.azure.net/secrets/db/v1")
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{ID: &id, Value: to.Ptr("old"), ContentType: to.Ptr("text/plain"), Tags: map[string]*string{"team": to.Ptr("a")}}}, nil
+	}, setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		set = p
+		id := azsecrets.ID("https://vlt.vault.azure.net/secrets/db/v2")
+		return azsecrets.SetSecretResponse{Secret: azsecrets.Secret{ID: &id}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	exp := time.Now().Add(time.Hour)
+	s, err := ksm.Rotate("db", "new", RotateOptions{DisablePrevious: true, Expiration: exp})
+	if err != nil || s.Version != "v2" || *set.Value != "new" || !set.SecretAttributes.Expires.Equal(exp) || *set.ContentType != "text/plain" || *set.Tags["team"] != "a" || len(f.updates) != 1 || f.updates[0].version != "v1" || *f.updates[0].p.SecretAttributes.Enabled {
+		t.Fatal(s, err, f.updates)
+	}
+	if strings.Contains(fmt.Sprint(s), "new") {
+		t.Fatal(s)
+	}
+	type key struct{}
+	f.updates = nil
+	ctx := context.WithValue(context.Background(), key{}, "rotate")
+	if _, err := ksm.rotate(ctx, "db", "newer", RotateOptions{DisablePrevious: true}); err != nil || len(f.updates) != 1 || f.updates[0].ctx.Value(key{}) != "rotate" {
+		t.Fatal("previous version not disabled under the rotation context:", err)
+	}
+	exists, f.updates = false, nil
+	if s, err := ksm.Rotate("db", "first", RotateOptions{DisablePrevious: true}); err != nil || s.Version != "v2" || len(f.updates) != 0 {
+		t.Fatal(s, err)
+	}
+}
@@ -0,0 +1,38 @@
+package azure
+
+import "sync"
+
+// VersionChangeHook is called when a Get returns another version of a secret
+// than the previous Get of the same name made through the client, which
+// usually means that the secret was rotated in between.
+type VersionChangeHook func(name, oldVersion, newVersion string)
+
+// versionTracker remembers the last version Get returned for every name.
+type versionTracker struct {
+	hook VersionChangeHook
+
+	mu   sync.Mutex
+	seen map[string]string
+}
+
+func newVersionTracker(hook VersionChangeHook) *versionTracker {
+	return &versionTracker{hook: hook, seen: make(map[string]string)}
+}
+
+// observe records that version of the secret was read and calls the hook
+// when it differs from the version read before. The first read of a name
+// only records it.
+func (t *versionTracker) observe(name, version string) {
+	if version == "" {
+		return
+	}
+
+	t.mu.Lock()
+	previous, ok := t.seen[name]
+	t.seen[name] = version
+	t.mu.Unlock()
+
+	if ok && previous != version {
+		t.hook(name, previous, version)
+	}
+}
@@ -0,0 +1,47 @@
+package azure
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestVersionChangeHook(t *testing.T) {
+	ver := "v1"
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		id := azsecrets.ID("https://vlt.vault.azure.net/secrets/" + name + "/" + ver)
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{ID: &id}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	var got []string
+	ksm.kvClient.versions = newVersionTracker(func(name, o, n string) { got = append(got, name+":"+o+">"+n) })
+	ksm.Get("a")
+	ksm.Get("a")
+	ver = "v2"
+	ksm.Get("a")
+	ksm.Get("a")
+	if len(got) != 1 || got[0] != "a:v1>v2" {
+		t.Fatal(got)
+	}
+}
+
+func TestVersionChangeHookAllGetters(t *testing.T) {
+	ver := "v1"
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		id := azsecrets.ID("https://vlt.vault.azure.net/secrets/" + name + "/" + ver)
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{ID: &id, Value: strp("v")}}, nil
+	}}
+	ksm := newTestManager(context.Background(), f)
+	var got []string
+	ksm.kvClient.versions = newVersionTracker(func(name, o, n string) { got = append(got, name+":"+o+">"+n) })
+	ksm.GetBytes("a")
+	ver = "v2"
+	ksm.GetSecure("a")
+	ver = "v3"
+	ksm.GetToWriter("a", io.Discard)
+	if len(got) != 2 || got[0] != "a:v1>v2" || got[1] != "a:v2>v3" {
+		t.Fatal(got)
+	}
+}
@@ -0,0 +1,65 @@
+package azure
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// nameAllowed reports whether the name passes the allowlist and denylist of
+// the client.
+func (kvc *KeyVaultClient) nameAllowed(name string) bool {
+	if matchesAny(kvc.nameDenylist, name) {
+		return false
+	}
+	return len(kvc.nameAllowlist) == 0 || matchesAny(kvc.nameAllowlist, name)
+}
+
+// nameScoped reports whether the client has an allowlist or a denylist of
+// names.
+func (kvc *KeyVaultClient) nameScoped() bool {
+	return len(kvc.nameAllowlist) > 0 || len(kvc.nameDenylist) > 0
+}
+
+// vaultSecretName returns the vault name of the secret the caller knows as
+// name, mapped with the name mapper of the client. It fails with a
+// Validation error when KeyVault would reject the name and an
+// InsufficientAccess error when the client is not allowed to touch it.
+// Scoping applies to vault names.
+func (kvc *KeyVaultClient) vaultSecretName(name string) (string, *errors.Error) {
+	if kvc.toVaultName != nil {
+		name = kvc.toVaultName(name)
+	}
+	if err := validateSecretName(name); err != nil {
+		return "", err
+	}
+	if !kvc.nameAllowed(name) {
+		return "", errors.InsufficientAccessError(fmt.Sprintf("secret %q is outside the names allowed for this client", name))
+	}
+	return name, nil
+}
+
+// callerSecretName maps a vault secret name back to the name the caller
+// knows it by.
+func (kvc *KeyVaultClient) callerSecretName(name string) string {
+	if kvc.fromVaultName != nil {
+		return kvc.fromVaultName(name)
+	}
+	return name
+}
+
+// matchesAny reports whether name matches one of the patterns. A malformed
+// pattern only matches a name equal to it.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			matched = pattern == name
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package azure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestScope(t *testing.T) {
+	called := false
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		called = true
+		return azsecrets.GetSecretResponse{}, nil
+	}, listPages: [][]*azsecrets.SecretProperties{props("app-a", "app-secret", "other")}}
+	ksm := newTestManager(context.Background(), f)
+	ksm.kvClient.nameAllowlist = []string{"app-*"}
+	ksm.kvClient.nameDenylist = []string{"*-secret"}
+	if _, err := ksm.Get("other"); err == nil || err.Status != 403 || called {
+		t.Fatal(err)
+	}
+	if _, err := ksm.Get("app-a"); err != nil || !called {
+		t.Fatal(err)
+	}
+	l, _ := ksm.List()
+	if len(l) != 1 || l[0].Name != "app-a" {
+		t.Fatal(l)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	f := &fakeOps{listPages: [][]*azsecrets.SecretProperties{{&azsecrets.SecretProperties{}}}}
+	ksm := newTestManager(context.Background(), f)
+	o := &recObs{}
+	WithObserver(o)(ksm.kvClient)
+	WithOperationNamePrefix("pay.")(ksm.kvClient)
+	ksm.List()
+	if len(o.msgs) != 1 || o.msgs[0][:16] != "pay.ListSecrets:" {
+		t.Fatal(o.msgs)
+	}
+}
+
+func TestMapper(t *testing.T) {
+	var gotName string
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		gotName = name
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("v")}}, nil
+	}, listPages: [][]*azsecrets.SecretProperties{props("db-password")}}
+	ksm := newTestManager(context.Background(), f)
+	WithNameMapper(DotsToDashes, DashesToDots)(ksm.kvClient)
+	s, _ := ksm.Get("db.password")
+	l, _ := ksm.List()
+	if gotName != "db-password" || s.Name != "db.password" || l[0].Name != "db.password" {
+		t.Fatal(gotName, s, l)
+	}
+}
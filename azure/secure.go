@@ -0,0 +1,99 @@
+package azure
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// GetToWriter writes the value of the secret with the given name to w, e.g. a
+// file or the stdin of a subprocess, without returning it as a Secret.
+//
+// The Azure SDK decodes the value into a string, which this package cannot
+// clear. GetToWriter keeps no reference to it and zeroes its own copy of the
+// bytes once written.
+func (ksm *KeyVaultSecretsManager) GetToWriter(name string, w io.Writer) *errors.Error {
+	resp, err := ksm.readSecret(ksm.kvClient.ctx, name, "")
+	if err != nil {
+		return err
+	}
+	if resp.Value == nil {
+		return errors.NotFoundError(fmt.Sprintf("secret %q has no value", name))
+	}
+
+	value := []byte(*resp.Value)
+	resp.Value = nil
+	defer clear(value)
+
+	if _, writeErr := w.Write(value); writeErr != nil {
+		return errors.InternalServerError(fmt.Sprintf("failed to write secret %q: %v", name, writeErr))
+	}
+
+	return nil
+}
+
+// SecureSecret holds a secret value in a byte slice that can be zeroed with
+// Destroy once the caller is done with it.
+//
+// This narrows, but does not close, the window in which the value sits in
+// memory: the Azure SDK still decodes the response into a string and the
+// HTTP body buffers that this package cannot clear, and the garbage
+// collector may have copied the slice before Destroy runs. Use it to
+// avoid the value lingering for the life of the process, not as a guarantee.
+type SecureSecret struct {
+	Name       string
+	Expiration time.Time
+	value      []byte
+	redaction  RedactionStyle
+}
+
+// Bytes returns the value. The slice is shared with the SecureSecret and is
+// zeroed by Destroy; copy it if it must outlive the secret.
+func (s *SecureSecret) Bytes() []byte {
+	return s.value
+}
+
+// Destroy zeroes the value. The SecureSecret is empty afterwards and Destroy
+// may be called again.
+func (s *SecureSecret) Destroy() {
+	clear(s.value)
+	s.value = nil
+}
+
+// String implements fmt.Stringer without revealing the value. It is on the
+// value receiver so that printing a SecureSecret or a pointer to one are
+// both covered.
+func (s SecureSecret) String() string {
+	if s.redaction == RedactOmit {
+		return fmt.Sprintf("SecureSecret{Name:%s}", s.Name)
+	}
+	return fmt.Sprintf("SecureSecret{Name:%s Value:%s}", s.Name, redactValue(s.redaction, len(s.value)))
+}
+
+// GoString implements fmt.GoStringer so that %#v does not reveal the value
+// either.
+func (s SecureSecret) GoString() string {
+	return s.String()
+}
+
+// GetSecure returns the latest version of the secret with the given name as
+// a SecureSecret. Callers should defer Destroy.
+func (ksm *KeyVaultSecretsManager) GetSecure(name string) (*SecureSecret, *errors.Error) {
+	resp, err := ksm.readSecret(ksm.kvClient.ctx, name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &SecureSecret{Name: name, redaction: ksm.kvClient.redactionStyle}
+	if resp.Value != nil {
+		secret.value = []byte(*resp.Value)
+		resp.Value = nil
+	}
+	if resp.Attributes != nil && resp.Attributes.Expires != nil {
+		secret.Expiration = *resp.Attributes.Expires
+	}
+
+	return secret, nil
+}
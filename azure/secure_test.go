@@ -0,0 +1,61 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestWriter(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("hunter2")}}, nil
+	}}
+	var b bytes.Buffer
+	if err := newTestManager(context.Background(), f).GetToWriter("a", &b); err != nil || b.String() != "hunter2" {
+		t.Fatal(err, b.String())
+	}
+}
+
+func TestSecure(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("hunter2")}}, nil
+	}}
+	s, _ := newTestManager(context.Background(), f).GetSecure("a")
+	b := s.Bytes()
+	s.Destroy()
+	for _, c := range b {
+		if c != 0 {
+			t.Fatal(b)
+		}
+	}
+	s.Destroy()
+}
+
+func TestSecureFormat(t *testing.T) {
+	f := &fakeOps{getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: strp("hunter2")}}, nil
+	}}
+	for _, style := range []RedactionStyle{RedactMask, RedactLength, RedactOmit} {
+		ksm := newTestManager(context.Background(), f)
+		ksm.kvClient.redactionStyle = style
+		s, _ := ksm.GetSecure("a")
+		for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+			for _, arg := range []any{s, *s} {
+				if out := fmt.Sprintf(format, arg); strings.Contains(out, "hunter2") || strings.Contains(out, "104") {
+					t.Fatalf("%v %s %T: %s", style, format, arg, out)
+				}
+			}
+		}
+	}
+}
+
+func TestSecureRedactLength(t *testing.T) {
+	s := SecureSecret{Name: "a", value: []byte("hunter2"), redaction: RedactLength}
+	if got := s.String(); got != "SecureSecret{Name:a Value:<redacted 7 bytes>}" {
+		t.Fatal(got)
+	}
+}
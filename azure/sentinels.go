@@ -0,0 +1,17 @@
+package azure
+
+import "github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+
+// Sentinel errors to match the errors returned by this package against with
+// the standard errors.Is, e.g. errors.Is(err, azure.ErrNotFound). They are
+// those of the errors package, repeated here so that callers need not import
+// a package named like the standard one.
+var (
+	ErrNotFound     = errors.ErrNotFound
+	ErrUnauthorized = errors.ErrUnauthorized
+	ErrForbidden    = errors.ErrForbidden
+	ErrConflict     = errors.ErrConflict
+	ErrThrottled    = errors.ErrThrottled
+	ErrTimeout      = errors.ErrTimeout
+	ErrCanceled     = errors.ErrCanceled
+)
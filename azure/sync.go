@@ -0,0 +1,118 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// SyncOptions controls Sync.
+type SyncOptions struct {
+	// Prefix restricts the sync to the secrets whose name starts with it.
+	Prefix string
+	// Overwrite updates the secrets of the destination whose value or
+	// attributes differ from the source. They are skipped otherwise.
+	Overwrite bool
+	// DryRun computes the report without writing to the destination.
+	DryRun bool
+}
+
+// SyncReport lists the outcome of Sync for every secret it considered. In a
+// dry run, Created and Updated list the secrets that would have been written.
+type SyncReport struct {
+	Created []string
+	Updated []string
+	Skipped []string
+	Failed  map[string]*errors.Error
+}
+
+// syncOutcome is what Sync did with a secret.
+type syncOutcome int
+
+const (
+	syncSkipped syncOutcome = iota
+	syncCreated
+	syncUpdated
+)
+
+// Sync copies the secrets of the vault into dst, e.g. to promote them from a
+// staging vault to a production one. Secrets that are already in dst are
+// skipped, unless Overwrite is set and they differ as told by
+// EqualIgnoringMeta, which takes reading them from dst.
+//
+// A failure on one secret is recorded in the report and does not stop the
+// others. The returned error is set when the vault cannot be listed or ctx is
+// done before every secret was handled.
+func (ksm *KeyVaultSecretsManager) Sync(ctx context.Context, dst IKeyVaultSecret, opts SyncOptions) (SyncReport, *errors.Error) {
+	report := SyncReport{Failed: make(map[string]*errors.Error)}
+
+	var names []string
+	err := ksm.walkSecrets(ctx, func(secret Secret) bool {
+		if strings.HasPrefix(secret.Name, opts.Prefix) {
+			names = append(names, secret.Name)
+		}
+		return true
+	})
+	if err != nil {
+		return report, err
+	}
+
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return report, ksm.kvClient.azError(ctx.Err())
+		}
+
+		outcome, err := ksm.syncSecret(ctx, dst, name, opts)
+		switch {
+		case err != nil:
+			report.Failed[name] = err
+		case outcome == syncCreated:
+			report.Created = append(report.Created, name)
+		case outcome == syncUpdated:
+			report.Updated = append(report.Updated, name)
+		default:
+			report.Skipped = append(report.Skipped, name)
+		}
+	}
+
+	return report, nil
+}
+
+// syncSecret syncs a single secret and reports what it did, or in a dry run
+// would have done, with it.
+func (ksm *KeyVaultSecretsManager) syncSecret(ctx context.Context, dst IKeyVaultSecret, name string, opts SyncOptions) (syncOutcome, *errors.Error) {
+	exists, err := dst.Exists(name)
+	if err != nil {
+		return syncSkipped, err
+	}
+	if exists && !opts.Overwrite {
+		return syncSkipped, nil
+	}
+
+	secret, err := ksm.get(ctx, name, "")
+	if err != nil {
+		return syncSkipped, err
+	}
+
+	outcome := syncCreated
+	if exists {
+		current, err := dst.Get(name)
+		if err != nil {
+			return syncSkipped, err
+		}
+		if EqualIgnoringMeta(*current, *secret) {
+			return syncSkipped, nil
+		}
+		outcome = syncUpdated
+	}
+
+	if opts.DryRun {
+		return outcome, nil
+	}
+	if err := dst.Set(*secret); err != nil {
+		return syncSkipped, err
+	}
+
+	return outcome, nil
+}
@@ -0,0 +1,26 @@
+package azure
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSync(t *testing.T) {
+	src := newTestManager(context.Background(), vaultFake())
+	src.Set(Secret{Name: "app-a", Value: "1"})
+	src.Set(Secret{Name: "app-b", Value: "2"})
+	src.Set(Secret{Name: "app-c", Value: "3"})
+	src.Set(Secret{Name: "other", Value: "4"})
+	dst := newCountingStore(Secret{Name: "app-b", Value: "old"}, Secret{Name: "app-c", Value: "3"})
+	r, err := src.Sync(context.Background(), dst, SyncOptions{Prefix: "app-", Overwrite: true, DryRun: true})
+	if err != nil || dst.sets != 0 || len(r.Created) != 1 || r.Created[0] != "app-a" || len(r.Updated) != 1 || r.Updated[0] != "app-b" || len(r.Skipped) != 1 || len(r.Failed) != 0 {
+		t.Fatal(r, err, dst.sets)
+	}
+	r, _ = src.Sync(context.Background(), dst, SyncOptions{Prefix: "app-"})
+	if dst.sets != 1 || len(r.Skipped) != 2 {
+		t.Fatal(r)
+	}
+	if s, _ := dst.Get("app-b"); s.Value != "old" {
+		t.Fatal(s)
+	}
+}
@@ -0,0 +1,32 @@
+package azure
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// Touch bumps the Updated date of the current version of the secret with the
+// given name, e.g. to signal that it is still in use, without changing its
+// value or creating a new version. It does so with an UpdateSecretProperties
+// call that changes no property, which KeyVault still records as an update.
+func (ksm *KeyVaultSecretsManager) Touch(name string) *errors.Error {
+	vaultName, err := ksm.kvClient.vaultSecretName(name)
+	if err != nil {
+		return err
+	}
+
+	params := azsecrets.UpdateSecretPropertiesParameters{
+		SecretAttributes: &azsecrets.SecretAttributes{},
+	}
+
+	start := ksm.kvClient.now()
+	err = ksm.kvClient.retry(ksm.kvClient.ctx, func() *errors.Error {
+		if _, err := ksm.secretsClient.UpdateSecretProperties(ksm.kvClient.ctx, vaultName, "", params, nil); err != nil {
+			return ksm.kvClient.azError(err)
+		}
+		return nil
+	})
+
+	return ksm.kvClient.operationError(ksm.kvClient.ctx, opUpdateSecretProperties, name, start, err)
+}
@@ -0,0 +1,18 @@
+package azure
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTouch(t *testing.T) {
+	before := time.Now().Add(-time.Hour)
+	f := &fakeOps{updated: map[string]time.Time{"a": before}}
+	if err := newTestManager(context.Background(), f).Touch("a"); err != nil {
+		t.Fatal(err)
+	}
+	if !f.updated["a"].After(before) || len(f.updates) != 1 || f.updates[0].version != "" || f.updates[0].p.SecretAttributes.Enabled != nil {
+		t.Fatal(f.updates)
+	}
+}
@@ -0,0 +1,108 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// UpdateProperties changes the attributes of the version secret.Version of the
+// secret, the current one when it is empty, without touching its value or
+// creating a new version, e.g. to extend an expiration. Expiration,
+// NotBefore, Enabled, ContentType and Tags are only changed when set; Tags
+// replace the existing ones as a whole, merged over the default tags.
+func (ksm *KeyVaultSecretsManager) UpdateProperties(secret Secret) *errors.Error {
+	return ksm.updateProperties(ksm.kvClient.ctx, secret)
+}
+
+// updateProperties is UpdateProperties under the given context.
+func (ksm *KeyVaultSecretsManager) updateProperties(ctx context.Context, secret Secret) *errors.Error {
+	vaultName, err := ksm.kvClient.vaultSecretName(secret.Name)
+	if err != nil {
+		return err
+	}
+	if err := validateValidity(secret); err != nil {
+		return err
+	}
+
+	params := azsecrets.UpdateSecretPropertiesParameters{
+		SecretAttributes: &azsecrets.SecretAttributes{Enabled: secret.Enabled},
+	}
+	if !secret.Expiration.IsZero() {
+		params.SecretAttributes.Expires = &secret.Expiration
+	}
+	if !secret.NotBefore.IsZero() {
+		params.SecretAttributes.NotBefore = &secret.NotBefore
+	}
+	if secret.ContentType != "" {
+		params.ContentType = &secret.ContentType
+	}
+	if secret.Tags != nil {
+		tags, err := ksm.replacementTags(ctx, secret)
+		if err != nil {
+			return err
+		}
+		params.Tags = toAzTags(tags)
+	}
+
+	start := ksm.kvClient.now()
+	err = ksm.kvClient.retry(ctx, func() *errors.Error {
+		if _, err := ksm.secretsClient.UpdateSecretProperties(ctx, vaultName, secret.Version, params, nil); err != nil {
+			return ksm.kvClient.azError(err)
+		}
+		return nil
+	})
+
+	return ksm.kvClient.operationError(ctx, opUpdateSecretProperties, secret.Name, start, err)
+}
+
+// replacementTags returns the tags UpdateProperties writes for secret. The
+// value encoding marker of the version is kept, as the value could not be
+// decoded anymore without it. It is read from the version properties, not
+// with the value, so that disabled versions can be updated too; the current
+// version is the most recently created one.
+func (ksm *KeyVaultSecretsManager) replacementTags(ctx context.Context, secret Secret) (map[string]string, *errors.Error) {
+	tags := mergeTags(ksm.kvClient.defaultTags, secret.Tags)
+	if err := checkReservedTags(secret.Name, tags); err != nil {
+		return nil, err
+	}
+
+	var version *azsecrets.SecretProperties
+	err := ksm.walkVersions(ctx, secret.Name, func(props *azsecrets.SecretProperties) {
+		if props.ID == nil {
+			return
+		}
+		if secret.Version != "" {
+			if props.ID.Version() == secret.Version {
+				version = props
+			}
+			return
+		}
+		if version == nil || createdOn(props).After(createdOn(version)) {
+			version = props
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if version == nil && secret.Version != "" {
+		return nil, errors.NotFoundError(fmt.Sprintf("secret %q has no version %q", secret.Name, secret.Version))
+	}
+	if version == nil {
+		return nil, errors.NotFoundError(fmt.Sprintf("secret %q not found", secret.Name))
+	}
+
+	if encoding := version.Tags[valueEncodingTag]; encoding != nil {
+		tags = maps.Clone(tags)
+		if tags == nil {
+			tags = map[string]string{}
+		}
+		tags[valueEncodingTag] = *encoding
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,56 @@
+package azure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestUpdateProperties(t *testing.T) {
+	sets := 0
+	enc := gzipEncoding
+	f := &fakeOps{setSecret: func(ctx context.Context, name string, p azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+		sets++
+		return azsecrets.SetSecretResponse{}, nil
+	}, getSecret: func(ctx context.Context, name, version string) (azsecrets.GetSecretResponse, error) {
+		return azsecrets.GetSecretResponse{}, respErr(403, `{"error":{"code":"Forbidden","message":"disabled","innererror":{"code":"SecretDisabled"}}}`, nil)
+	}}
+	v0, v1 := azsecrets.ID("https://vlt.vault.azure.net/secrets/a/v0"), azsecrets.ID("https://vlt.vault.azure.net/secrets/a/v1")
+	old, created := time.Unix(100, 0), time.Unix(200, 0)
+	f.versionPages = map[string][][]*azsecrets.SecretProperties{"a": {{
+		{ID: &v0, Attributes: &azsecrets.SecretAttributes{Created: &old}},
+		{ID: &v1, Attributes: &azsecrets.SecretAttributes{Created: &created, Enabled: to.Ptr(false)}, Tags: map[string]*string{valueEncodingTag: &enc, "old": &enc}},
+	}}}
+	ksm := newTestManager(context.Background(), f)
+	exp := time.Now().Add(time.Hour)
+	if err := ksm.UpdateProperties(Secret{Name: "a", Expiration: exp}); err != nil {
+		t.Fatal(err)
+	}
+	u := f.updates[0]
+	if sets != 0 || u.version != "" || !u.p.SecretAttributes.Expires.Equal(exp) || u.p.Tags != nil || u.p.SecretAttributes.Enabled != nil || u.p.ContentType != nil {
+		t.Fatal(u)
+	}
+	ksm.UpdateProperties(Secret{Name: "a", Version: "v1", Tags: map[string]string{"k": "v"}})
+	u = f.updates[1]
+	if u.version != "v1" || len(u.p.Tags) != 2 || *u.p.Tags[valueEncodingTag] != gzipEncoding || u.p.Tags["old"] != nil {
+		t.Fatal(u)
+	}
+	if err := ksm.UpdateProperties(Secret{Name: "a", Tags: map[string]string{"k": "v"}}); err != nil {
+		t.Fatal(err)
+	}
+	if u = f.updates[2]; len(u.p.Tags) != 2 || *u.p.Tags[valueEncodingTag] != gzipEncoding {
+		t.Fatal(u)
+	}
+	ksm.UpdateProperties(Secret{Name: "a", Version: "v0", Tags: map[string]string{"k": "v"}})
+	if u = f.updates[3]; len(u.p.Tags) != 1 {
+		t.Fatal(u)
+	}
+	if err := ksm.UpdateProperties(Secret{Name: "a", Version: "v7", Tags: map[string]string{"k": "v"}}); err == nil || err.Code != errors.ErrCodeNotFound {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,146 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+// VersionCount returns how many versions exist for the secret with the given
+// name. It pages through the version properties only, so no value is fetched.
+func (ksm *KeyVaultSecretsManager) VersionCount(name string) (int, *errors.Error) {
+	count := 0
+
+	err := ksm.walkVersions(ksm.kvClient.ctx, name, func(*azsecrets.SecretProperties) {
+		count++
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ListVersions returns every version of the secret with the given name,
+// without values, each with its version and attributes. A secret that has no
+// version is a NotFound error rather than an empty list.
+func (ksm *KeyVaultSecretsManager) ListVersions(name string) ([]Secret, *errors.Error) {
+	var versions []Secret
+	err := ksm.walkVersions(ksm.kvClient.ctx, name, func(props *azsecrets.SecretProperties) {
+		if props.ID == nil {
+			return
+		}
+		secret := Secret{Name: name, Version: props.ID.Version()}
+		applyAttributes(&secret, props.Attributes)
+		secret.ContentType = derefString(props.ContentType)
+		secret.Tags = fromAzTags(props.Tags)
+		versions = append(versions, secret)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, errors.NotFoundError(fmt.Sprintf("secret %q not found", name))
+	}
+
+	return versions, nil
+}
+
+// PruneVersions disables every version of the secret but the keep most
+// recently created ones, and returns the versions it disabled. KeyVault
+// cannot delete a single version, so disabling is the closest there is to
+// removing one. Versions that are already disabled are left alone.
+func (ksm *KeyVaultSecretsManager) PruneVersions(name string, keep int) ([]string, *errors.Error) {
+	if keep < 0 {
+		return nil, errors.ValidationError(fmt.Sprintf("cannot keep %d versions of secret %q", keep, name))
+	}
+
+	var versions []*azsecrets.SecretProperties
+	err := ksm.walkVersions(ksm.kvClient.ctx, name, func(props *azsecrets.SecretProperties) {
+		versions = append(versions, props)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) <= keep {
+		return nil, nil
+	}
+
+	// Newest first; versions without a creation date sort last.
+	sort.SliceStable(versions, func(i, j int) bool {
+		return createdOn(versions[i]).After(createdOn(versions[j]))
+	})
+
+	vaultName, err := ksm.kvClient.vaultSecretName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var disabled []string
+	for _, props := range versions[keep:] {
+		if props.ID == nil || (props.Attributes != nil && props.Attributes.Enabled != nil && !*props.Attributes.Enabled) {
+			continue
+		}
+
+		version := props.ID.Version()
+		params := azsecrets.UpdateSecretPropertiesParameters{
+			SecretAttributes: &azsecrets.SecretAttributes{Enabled: to.Ptr(false)},
+		}
+		start := ksm.kvClient.now()
+		err := ksm.kvClient.retry(ksm.kvClient.ctx, func() *errors.Error {
+			if _, err := ksm.secretsClient.UpdateSecretProperties(ksm.kvClient.ctx, vaultName, version, params, nil); err != nil {
+				return ksm.kvClient.azError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return disabled, ksm.kvClient.operationError(ksm.kvClient.ctx, opUpdateSecretProperties, name, start, err)
+		}
+		disabled = append(disabled, version)
+	}
+
+	return disabled, nil
+}
+
+// walkVersions pages through the version properties of the secret with the
+// given name and calls fn with each of them, stopping as soon as ctx is done.
+func (ksm *KeyVaultSecretsManager) walkVersions(ctx context.Context, name string, fn func(*azsecrets.SecretProperties)) *errors.Error {
+	vaultName, err := ksm.kvClient.vaultSecretName(name)
+	if err != nil {
+		return err
+	}
+
+	start := ksm.kvClient.now()
+	pager := ksm.secretsClient.NewListSecretPropertiesVersionsPager(vaultName, nil)
+	for pager.More() {
+		if err := ctx.Err(); err != nil {
+			return ksm.kvClient.operationError(ctx, opListSecretVersions, name, start, ksm.kvClient.azError(err))
+		}
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return ksm.kvClient.operationError(ctx, opListSecretVersions, name, start, ksm.kvClient.azError(err))
+		}
+		for _, props := range page.Value {
+			if props != nil {
+				fn(props)
+			}
+		}
+	}
+
+	return nil
+}
+
+// createdOn returns the creation date of a version, or the zero time.
+func createdOn(props *azsecrets.SecretProperties) time.Time {
+	if props.Attributes == nil || props.Attributes.Created == nil {
+		return time.Time{}
+	}
+	return *props.Attributes.Created
+}
@@ -0,0 +1,57 @@
+package azure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+
+	"github.com/danjelhysenaj-dev/azure-keyvault-sdk-go/errors"
+)
+
+func TestVersionCount(t *testing.T) {
+	f := &fakeOps{versionPages: map[string][][]*azsecrets.SecretProperties{"a": {props("a/1", "a/2"), props("a/3")}}}
+	n, err := newTestManager(context.Background(), f).VersionCount("a")
+	if err != nil || n != 3 {
+		t.Fatal(n, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := newTestManager(ctx, f).VersionCount("a"); err == nil {
+		t.Fatal("want err")
+	}
+	e := checkAzErrResp(respErr(403, `{"error":{"code":"Forbidden","message":"nope"}}`, nil))
+	if e.Status != 403 || e.Message != "nope" {
+		t.Fatal(e)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	p := props("a/v1", "a/v2", "a/v3", "a/v4")
+	base := time.Unix(1000, 0)
+	for i, x := range []int{1, 3, 2, 4} {
+		c := base.Add(time.Duration(x) * time.Hour)
+		p[i].Attributes = &azsecrets.SecretAttributes{Created: &c}
+	}
+	f := &fakeOps{versionPages: map[string][][]*azsecrets.SecretProperties{"a": {p[:2], p[2:]}}}
+	d, err := newTestManager(context.Background(), f).PruneVersions("a", 2)
+	if err != nil || len(d) != 2 || d[0] != "v3" || d[1] != "v1" || *f.updates[0].p.SecretAttributes.Enabled {
+		t.Fatal(d, err)
+	}
+}
+
+func TestListVersions(t *testing.T) {
+	p := props("a/v1", "a/v2")
+	exp := time.Unix(5000, 0)
+	p[1].Attributes = &azsecrets.SecretAttributes{Expires: &exp}
+	f := &fakeOps{versionPages: map[string][][]*azsecrets.SecretProperties{"a": {p}}}
+	ksm := newTestManager(context.Background(), f)
+	vs, err := ksm.ListVersions("a")
+	if err != nil || len(vs) != 2 || vs[0].Version != "v1" || !vs[1].Expiration.Equal(exp) || vs[1].Name != "a" {
+		t.Fatal(vs, err)
+	}
+	if _, err := ksm.ListVersions("missing"); err == nil || err.Code != errors.ErrCodeNotFound {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,274 @@
+// Package errors defines the error type returned by every operation of this
+// module, so callers can inspect failures through a stable Code and Status
+// instead of the raw Azure SDK errors.
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Code classifies an Error. It serializes as its string value.
+type Code string
+
+// Error codes carried by Error.Code.
+const (
+	ErrCodeBadRequest          Code = "BadRequest"
+	ErrCodeNotFound            Code = "NotFound"
+	ErrCodeUnauthorized        Code = "Unauthorized"
+	ErrCodeInsufficientAccess  Code = "InsufficientAccess"
+	ErrCodeForbiddenByFirewall Code = "ForbiddenByFirewall"
+	ErrCodeSecretDisabled      Code = "SecretDisabled"
+	ErrCodeConflict            Code = "Conflict"
+	ErrCodePurgeProtected      Code = "PurgeProtected"
+	ErrCodeThrottled           Code = "Throttled"
+	ErrCodeInternalServerError Code = "InternalServerError"
+	ErrCodeBadGateway          Code = "BadGateway"
+	ErrCodeServiceUnavailable  Code = "ServiceUnavailable"
+	ErrCodeTimeout             Code = "Timeout"
+	ErrCodeCanceled            Code = "Canceled"
+	ErrCodeValidation          Code = "ValidationError"
+)
+
+// String implements fmt.Stringer.
+func (c Code) String() string {
+	return string(c)
+}
+
+// Error describes a failed KeyVault operation.
+type Error struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+	TraceId string `json:"traceId"`
+	// RetryAfter is how long KeyVault asked the caller to wait before
+	// retrying, when it did, e.g. for a Throttled error.
+	RetryAfter time.Duration `json:"retryAfter,omitempty"`
+
+	cause error
+}
+
+// Sentinel errors to match an Error against with the standard errors.Is, e.g.
+// errors.Is(err, ErrNotFound). ErrForbidden matches every 403, whatever the
+// reason KeyVault gave.
+var (
+	ErrNotFound     = &Error{Code: ErrCodeNotFound, Status: http.StatusNotFound}
+	ErrUnauthorized = &Error{Code: ErrCodeUnauthorized, Status: http.StatusUnauthorized}
+	ErrForbidden    = &Error{Status: http.StatusForbidden}
+	ErrConflict     = &Error{Code: ErrCodeConflict, Status: http.StatusConflict}
+	ErrThrottled    = &Error{Code: ErrCodeThrottled, Status: http.StatusTooManyRequests}
+	ErrTimeout      = &Error{Code: ErrCodeTimeout, Status: http.StatusGatewayTimeout}
+	ErrCanceled     = &Error{Code: ErrCodeCanceled, Status: statusClientClosedRequest}
+)
+
+// statusClientClosedRequest is the status of Canceled errors. It is not a
+// standard HTTP status but the one proxies such as nginx log for requests
+// the client gave up on, which is what a cancelled context is.
+const statusClientClosedRequest = 499
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (%d): %s", e.Code, e.Status, e.Message)
+}
+
+// Is reports whether target is an Error with the same Code, or with no Code
+// and the same Status, so that errors.Is can match e against the sentinels.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t.Code == "" {
+		return t.Status == e.Status
+	}
+	return t.Code == e.Code
+}
+
+// Unwrap returns the error e was mapped from, e.g. an *azcore.ResponseError,
+// or nil.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// WithCause records err as the error e was mapped from, for Unwrap, and
+// returns e.
+func (e *Error) WithCause(err error) *Error {
+	e.cause = err
+	return e
+}
+
+// IsTransient reports whether the failure is likely to go away on its own, so
+// that retrying the operation later makes sense.
+func (e *Error) IsTransient() bool {
+	switch e.Code {
+	case ErrCodeThrottled, ErrCodeBadGateway, ErrCodeServiceUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// BadRequestError is returned when KeyVault rejected the request as
+// malformed, e.g. because of an invalid secret name or parameter.
+func BadRequestError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeBadRequest,
+		Message: message,
+		Status:  http.StatusBadRequest,
+		TraceId: "",
+	}
+}
+
+// NotFoundError is returned when the requested resource does not exist.
+func NotFoundError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeNotFound,
+		Message: message,
+		Status:  http.StatusNotFound,
+		TraceId: "",
+	}
+}
+
+// UnauthorizedError is returned when the caller could not be authenticated.
+func UnauthorizedError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeUnauthorized,
+		Message: message,
+		Status:  http.StatusUnauthorized,
+		TraceId: "",
+	}
+}
+
+// InsufficientAccessError is returned when the caller is authenticated but
+// not allowed to perform the operation.
+func InsufficientAccessError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeInsufficientAccess,
+		Message: message,
+		Status:  http.StatusForbidden,
+		TraceId: "",
+	}
+}
+
+// ForbiddenByFirewallError is returned when the network rules of the vault
+// rejected the caller, as opposed to its access policies or RBAC.
+func ForbiddenByFirewallError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeForbiddenByFirewall,
+		Message: message,
+		Status:  http.StatusForbidden,
+		TraceId: "",
+	}
+}
+
+// ThrottledError is returned when KeyVault rejected the request because the
+// caller exceeded its request rate.
+func ThrottledError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeThrottled,
+		Message: message,
+		Status:  http.StatusTooManyRequests,
+		TraceId: "",
+	}
+}
+
+// SecretDisabledError is returned when reading a secret whose current
+// version is disabled.
+func SecretDisabledError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeSecretDisabled,
+		Message: message,
+		Status:  http.StatusForbidden,
+		TraceId: "",
+	}
+}
+
+// ConflictError is returned when the request conflicts with the state of the
+// vault, e.g. when setting a secret whose name is taken by a soft-deleted
+// one.
+func ConflictError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeConflict,
+		Message: message,
+		Status:  http.StatusConflict,
+		TraceId: "",
+	}
+}
+
+// PurgeProtectedError is returned when purging a deleted secret is refused
+// because purge protection is enabled on the vault.
+func PurgeProtectedError(message string) *Error {
+	return &Error{
+		Code:    ErrCodePurgeProtected,
+		Message: message,
+		Status:  http.StatusForbidden,
+		TraceId: "",
+	}
+}
+
+// InternalServerError is returned for every failure that has no more
+// specific code.
+func InternalServerError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeInternalServerError,
+		Message: message,
+		Status:  http.StatusInternalServerError,
+		TraceId: "",
+	}
+}
+
+// BadGatewayError is returned when a gateway in front of KeyVault failed to
+// get a response from it.
+func BadGatewayError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeBadGateway,
+		Message: message,
+		Status:  http.StatusBadGateway,
+		TraceId: "",
+	}
+}
+
+// ServiceUnavailableError is returned when KeyVault is temporarily unable to
+// serve the request.
+func ServiceUnavailableError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeServiceUnavailable,
+		Message: message,
+		Status:  http.StatusServiceUnavailable,
+		TraceId: "",
+	}
+}
+
+// TimeoutError is returned when an operation ran out of the deadline of its
+// context.
+func TimeoutError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeTimeout,
+		Message: message,
+		Status:  http.StatusGatewayTimeout,
+		TraceId: "",
+	}
+}
+
+// CanceledError is returned when an operation was given up because its
+// context was cancelled, e.g. by the caller, before it completed.
+func CanceledError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeCanceled,
+		Message: message,
+		Status:  statusClientClosedRequest,
+		TraceId: "",
+	}
+}
+
+// ValidationError is returned when an input or a secret fails a check made
+// by this module, before or instead of calling KeyVault.
+func ValidationError(message string) *Error {
+	return &Error{
+		Code:    ErrCodeValidation,
+		Message: message,
+		Status:  http.StatusBadRequest,
+		TraceId: "",
+	}
+}
@@ -0,0 +1,15 @@
+package errors
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestErrorJSON(t *testing.T) {
+	b, _ := json.Marshal(NotFoundError("x"))
+	var e Error
+	json.Unmarshal(b, &e)
+	if string(b) != `{"code":"NotFound","message":"x","status":404,"traceId":""}` || e.Code != ErrCodeNotFound {
+		t.Fatal(string(b))
+	}
+}